@@ -2,7 +2,8 @@ package aggregator
 
 import (
 	"context"
-	"math/big"
+	"encoding/binary"
+	"fmt"
 	"sync"
 	"time"
 
@@ -10,16 +11,25 @@ import (
 
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients"
 	sdkclients "github.com/Layr-Labs/eigensdk-go/chainio/clients"
+	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	"github.com/Layr-Labs/eigensdk-go/services/avsregistry"
 	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
 	oprsinfoserv "github.com/Layr-Labs/eigensdk-go/services/operatorsinfo"
 	sdktypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/Layr-Labs/incredible-squaring-avs/aggregator/metrics"
+	"github.com/Layr-Labs/incredible-squaring-avs/aggregator/store"
 	"github.com/Layr-Labs/incredible-squaring-avs/aggregator/types"
 	"github.com/Layr-Labs/incredible-squaring-avs/core"
 	"github.com/Layr-Labs/incredible-squaring-avs/core/chainio"
 	"github.com/Layr-Labs/incredible-squaring-avs/core/config"
+	"github.com/Layr-Labs/incredible-squaring-avs/core/reorg"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
 
-	cstaskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/IncredibleSquaringTaskManager"
+	jobmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/KeeperNetworkJobManager"
+	taskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/KeeperNetworkTaskManager"
 )
 
 const (
@@ -28,52 +38,69 @@ const (
 	// ideally be fetched from the contracts
 	taskChallengeWindowBlock = 100
 	blockTimeSeconds         = 12 * time.Second
-	avsName                  = "incredible-squaring"
+	avsName                  = "keeper-network"
+	defaultDbPath            = "aggregator.db"
+	defaultMetricsIpPortAddr = ":9090"
 )
 
-// Aggregator sends tasks (numbers to square) onchain, then listens for operator signed TaskResponses.
-// It aggregates responses signatures, and if any of the TaskResponses reaches the QuorumThresholdPercentage for each quorum
-// (currently we only use a single quorum of the ERC20Mock token), it sends the aggregated TaskResponse and signature onchain.
+// Aggregator listens for Keeper tasks created onchain, then for keeper signed TaskResponses.
+// It aggregates responses signatures, and if any of the TaskResponses reaches the QuorumThresholdPercentage
+// of the task's job in each of the job's quorums, it sends the aggregated TaskResponse onchain through
+// KeeperNetworkTaskManager.respondToTask.
 //
-// The signature is checked in the BLSSignatureChecker.sol contract, which expects a
+// The quorums and threshold are not part of the TaskCreated event, so the aggregator reads them from
+// KeeperNetworkJobManager.jobs, and caches every job it sees a JobCreated event for.
 //
-//	struct NonSignerStakesAndSignature {
-//		uint32[] nonSignerQuorumBitmapIndices;
-//		BN254.G1Point[] nonSignerPubkeys;
-//		BN254.G1Point[] quorumApks;
-//		BN254.G2Point apkG2;
-//		BN254.G1Point sigma;
-//		uint32[] quorumApkIndices;
-//		uint32[] totalStakeIndices;
-//		uint32[][] nonSignerStakeIndices; // nonSignerStakeIndices[quorumNumberIndex][nonSignerIndex]
-//	}
+// Along with the TaskResponse, respondToTask takes the pubkeys of the operators who did not sign it
+// (nonSignerPubkeys) and a TaskResponseMetadata whose hashOfNonSigners commits to them.
+// nonSignerPubkeys are the G1 pubkeys of the operators who did not sign the task response, but were opted
+// into the quorum at the blocknumber at which the task was created. The bls aggregation service gets the list
+// of all operators opted into each quorum at that block number by calling the getOperatorState() function of
+// the BLSOperatorStateRetriever.sol contract.
 //
-// A task can only be responded onchain by having enough operators sign on it such that their stake in each quorum reaches the QuorumThresholdPercentage.
-// In order to verify this onchain, the Registry contracts store the history of stakes and aggregate pubkeys (apks) for each operators and each quorum. These are
-// updated everytime an operator registers or deregisters with the BLSRegistryCoordinatorWithIndices.sol contract, or calls UpdateStakes() on the StakeRegistry.sol contract,
-// after having received new delegated shares or having delegated shares removed by stakers queuing withdrawals. Each of these pushes to their respective datatype array a new entry.
+// Tasks, task responses and signatures are also written to an on-disk store. When the aggregator
+// starts, it re-initializes aggregation for the stored tasks that have not expired and replays
+// their signatures, so a restart does not lose the responses keepers already sent.
 //
-// This is true for quorumBitmaps (represent the quorums each operator is opted into), quorumApks (apks per quorum), totalStakes (total stake per quorum), and nonSignerStakes (stake per quorum per operator).
-// The 4 "indices" in NonSignerStakesAndSignature basically represent the index at which to fetch their respective data, given a blockNumber at which the task was created.
-// Note that different data types might have different indices, since for eg QuorumBitmaps are updated for operators registering/deregistering, but not for UpdateStakes.
-// Thankfully, we have deployed a helper contract BLSOperatorStateRetriever.sol whose function getCheckSignaturesIndices() can be used to fetch the indices given a block number.
+// Tasks the aggregator cannot respond to (see types.TaskFailureReason) are recorded as failed in the
+// store, counted in the aggregator_tasks_failed metric, and optionally marked failed onchain.
 //
-// The 4 other fields nonSignerPubkeys, quorumApks, apkG2, and sigma, however, must be computed individually.
-// apkG2 and sigma are just the aggregated signature and pubkeys of the operators who signed the task response (aggregated over all quorums, so individual signatures might be duplicated).
-// quorumApks are the G1 aggregated pubkeys of the operators who signed the task response, but one per quorum, as opposed to apkG2 which is summed over all quorums.
-// nonSignerPubkeys are the G1 pubkeys of the operators who did not sign the task response, but were opted into the quorum at the blocknumber at which the task was created.
-// Upon sending a task onchain (or receiving a NewTaskCreated Event if the tasks were sent by an external task generator), the aggregator can get the list of all operators opted into each quorum at that
-// block number by calling the getOperatorState() function of the BLSOperatorStateRetriever.sol contract.
+// JobCreated and TaskCreated events are only acted on once ConfirmationDepth blocks were built on top
+// of theirs. If a reorg still removes one afterwards, the job is dropped from the cache, or the task is
+// forgotten and its aggregated response dropped. When the new chain includes the event again, it is
+// acted on again, and a task whose aggregation was still running picks it up where it was.
 type Aggregator struct {
 	logger           logging.Logger
 	serverIpPortAddr string
+	ethClient        eth.Client
+	avsReader        chainio.AvsReaderer
 	avsWriter        chainio.AvsWriterer
+	avsSubscriber    chainio.AvsSubscriberer
 	// aggregation related fields
 	blsAggregationService blsagg.BlsAggregationService
-	tasks                 map[types.TaskIndex]cstaskmanager.IIncredibleSquaringTaskManagerTask
-	tasksMu               sync.RWMutex
-	taskResponses         map[types.TaskIndex]map[sdktypes.TaskResponseDigest]cstaskmanager.IIncredibleSquaringTaskManagerTaskResponse
-	taskResponsesMu       sync.RWMutex
+	avsRegistryService    avsregistry.AvsRegistryService
+	taskResponseDomain    core.TaskResponseDomain
+	jobs                  map[uint32]chainio.Job
+	jobsMu                sync.RWMutex
+	tasks                 map[types.TaskIndex]types.Task
+	// removedTasks are the tasks a reorg removed while the bls aggregation service still aggregates them
+	removedTasks    map[types.TaskIndex]types.Task
+	tasksMu         sync.RWMutex
+	taskResponses   map[types.TaskIndex]map[sdktypes.TaskResponseDigest]core.TaskResponse
+	taskResponsesMu sync.RWMutex
+	store           *store.Store
+	// serializes the duplicate check and write of an operator's signature
+	signaturesMu sync.Mutex
+	// the digest of the upkeep response recorded for each task, see ProcessSignedUpkeepResponse
+	upkeeps   map[types.TaskIndex]sdktypes.TaskResponseDigest
+	upkeepsMu sync.Mutex
+	// serializes the aggregator's transactions, which are sent from several goroutines
+	txMu                      sync.Mutex
+	updateTaskStatusOnFailure bool
+	confirmationDepth         uint64
+	metrics                   metrics.Metrics
+	metricsReg                *prometheus.Registry
+	enableMetrics             bool
 }
 
 // NewAggregator creates a new Aggregator with the provided config.
@@ -91,105 +118,361 @@ func NewAggregator(c *config.Config) (*Aggregator, error) {
 		return nil, err
 	}
 
+	metricsIpPortAddr := c.EigenMetricsIpPortAddress
+	if metricsIpPortAddr == "" {
+		metricsIpPortAddr = defaultMetricsIpPortAddr
+	}
 	chainioConfig := sdkclients.BuildAllConfig{
 		EthHttpUrl:                 c.EthHttpRpcUrl,
 		EthWsUrl:                   c.EthWsRpcUrl,
 		RegistryCoordinatorAddr:    c.IncredibleSquaringRegistryCoordinatorAddr.String(),
 		OperatorStateRetrieverAddr: c.OperatorStateRetrieverAddr.String(),
 		AvsName:                    avsName,
-		PromMetricsIpPortAddress:   ":9090",
+		PromMetricsIpPortAddress:   metricsIpPortAddr,
 	}
 	clients, err := clients.BuildAll(chainioConfig, c.EcdsaPrivateKey, c.Logger)
 	if err != nil {
 		c.Logger.Errorf("Cannot create sdk clients", "err", err)
 		return nil, err
 	}
+	aggregatorMetrics := metrics.NewAggregatorMetrics(clients.Metrics, clients.PrometheusRegistry)
+
+	avsSubscriber, err := chainio.BuildAvsSubscriberFromConfig(c, aggregatorMetrics)
+	if err != nil {
+		c.Logger.Errorf("Cannot create avsSubscriber", "err", err)
+		return nil, err
+	}
 
 	operatorPubkeysService := oprsinfoserv.NewOperatorsInfoServiceInMemory(context.Background(), clients.AvsRegistryChainSubscriber, clients.AvsRegistryChainReader, c.Logger)
 	avsRegistryService := avsregistry.NewAvsRegistryServiceChainCaller(avsReader, operatorPubkeysService, c.Logger)
 	blsAggregationService := blsagg.NewBlsAggregatorService(avsRegistryService, c.Logger)
 
+	chainId, err := c.EthHttpClient.ChainID(context.Background())
+	if err != nil {
+		c.Logger.Error("Cannot get chain id", "err", err)
+		return nil, err
+	}
+
+	dbPath := c.AggregatorDbPath
+	if dbPath == "" {
+		dbPath = defaultDbPath
+	}
+	aggregatorStore, err := store.Open(dbPath)
+	if err != nil {
+		c.Logger.Error("Cannot open aggregator store", "err", err)
+		return nil, err
+	}
+
 	return &Aggregator{
 		logger:                c.Logger,
 		serverIpPortAddr:      c.AggregatorServerIpPortAddr,
+		ethClient:             c.EthHttpClient,
+		avsReader:             avsReader,
 		avsWriter:             avsWriter,
+		avsSubscriber:         avsSubscriber,
 		blsAggregationService: blsAggregationService,
-		tasks:                 make(map[types.TaskIndex]cstaskmanager.IIncredibleSquaringTaskManagerTask),
-		taskResponses:         make(map[types.TaskIndex]map[sdktypes.TaskResponseDigest]cstaskmanager.IIncredibleSquaringTaskManagerTaskResponse),
+		avsRegistryService:    avsRegistryService,
+		taskResponseDomain: core.TaskResponseDomain{
+			ChainId:     chainId,
+			TaskManager: c.KeeperNetworkTaskManagerAddr,
+		},
+		jobs:          make(map[uint32]chainio.Job),
+		tasks:         make(map[types.TaskIndex]types.Task),
+		removedTasks:  make(map[types.TaskIndex]types.Task),
+		taskResponses: make(map[types.TaskIndex]map[sdktypes.TaskResponseDigest]core.TaskResponse),
+		upkeeps:       make(map[types.TaskIndex]sdktypes.TaskResponseDigest),
+		store:         aggregatorStore,
+
+		updateTaskStatusOnFailure: c.UpdateTaskStatusOnFailure,
+		confirmationDepth:         c.ConfirmationDepth,
+		metrics:                   aggregatorMetrics,
+		metricsReg:                clients.PrometheusRegistry,
+		enableMetrics:             c.EnableMetrics,
 	}, nil
 }
 
 func (agg *Aggregator) Start(ctx context.Context) error {
 	agg.logger.Infof("Starting aggregator.")
+	defer agg.store.Close()
+	recoveredTasks, err := agg.recoverTasks()
+	if err != nil {
+		return err
+	}
+	var metricsErrChan <-chan error
+	if agg.enableMetrics {
+		metricsErrChan = agg.metrics.Start(ctx, agg.metricsReg)
+	} else {
+		metricsErrChan = make(chan error, 1)
+	}
 	agg.logger.Infof("Starting aggregator rpc server.")
 	go agg.startServer(ctx)
+	// replayed signatures can complete a task, so this has to run alongside the loop below
+	// which consumes the bls aggregation service's responses
+	go agg.replaySignatures(ctx, recoveredTasks)
 
-	// TODO(soubhik): refactor task generation/sending into a separate function that we can run as goroutine
-	ticker := time.NewTicker(10 * time.Second)
-	agg.logger.Infof("Aggregator set to send new task every 10 seconds...")
-	defer ticker.Stop()
-	taskNum := int64(0)
-	// ticker doesn't tick immediately, so we send the first task here
-	// see https://github.com/golang/go/issues/17601
-	_ = agg.sendNewTask(big.NewInt(taskNum))
-	taskNum++
+	// the subscriptions reconnect, or poll, by themselves when the websocket fails
+	newJobCreatedChan := make(chan *jobmanager.ContractKeeperNetworkJobManagerJobCreated)
+	jobSub := agg.avsSubscriber.SubscribeToNewJobs(newJobCreatedChan)
+	defer jobSub.Unsubscribe()
+	newTaskCreatedChan := make(chan *taskmanager.ContractKeeperNetworkTaskManagerTaskCreated)
+	taskSub := agg.avsSubscriber.SubscribeToNewTasks(newTaskCreatedChan)
+	defer taskSub.Unsubscribe()
+	newHeadChan := make(chan *gethtypes.Header)
+	headSub := agg.avsSubscriber.SubscribeToNewHeads(newHeadChan)
+	defer headSub.Unsubscribe()
+	confirmer := reorg.NewConfirmer[any](agg.confirmationDepth, agg.ethClient)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
+		case err := <-metricsErrChan:
+			// TODO: handle gracefully
+			agg.logger.Fatal("Error in metrics server", "err", err)
+		case head := <-newHeadChan:
+			update, err := confirmer.AddHead(ctx, head)
+			if err != nil {
+				agg.logger.Error("Failed to track new head", "blockNumber", head.Number, "err", err)
+				continue
+			}
+			agg.handleEventUpdate(ctx, update)
+		case newJobCreatedLog := <-newJobCreatedChan:
+			agg.logger.Info("Received JobCreated event", "jobId", newJobCreatedLog.JobId, "jobType", newJobCreatedLog.JobType,
+				"blockNumber", newJobCreatedLog.Raw.BlockNumber, "removed", newJobCreatedLog.Raw.Removed)
+			agg.handleEventUpdate(ctx, confirmer.AddLog(newJobCreatedLog.Raw, newJobCreatedLog))
+		case newTaskCreatedLog := <-newTaskCreatedChan:
+			agg.logger.Info("Received TaskCreated event", "taskId", newTaskCreatedLog.TaskId, "jobId", newTaskCreatedLog.JobId,
+				"blockNumber", newTaskCreatedLog.Raw.BlockNumber, "removed", newTaskCreatedLog.Raw.Removed)
+			agg.handleEventUpdate(ctx, confirmer.AddLog(newTaskCreatedLog.Raw, newTaskCreatedLog))
 		case blsAggServiceResp := <-agg.blsAggregationService.GetResponseChannel():
 			agg.logger.Info("Received response from blsAggregationService", "blsAggServiceResp", blsAggServiceResp)
-			agg.sendAggregatedResponseToContract(blsAggServiceResp)
-		case <-ticker.C:
-			err := agg.sendNewTask(big.NewInt(taskNum))
-			taskNum++
-			if err != nil {
-				// we log the errors inside sendNewTask() so here we just continue to the next task
+			if blsAggServiceResp.Err != nil {
+				go agg.handleAggregationError(ctx, blsAggServiceResp.Err)
+				continue
+			}
+			if !agg.hasTask(blsAggServiceResp.TaskIndex) {
+				agg.logger.Warn("Dropping aggregated response of a task removed by a reorg", "taskIndex", blsAggServiceResp.TaskIndex)
+				agg.dropRemovedTask(blsAggServiceResp.TaskIndex)
 				continue
 			}
+			// sending can take several attempts, so it must not hold up the loop
+			go agg.sendAggregatedResponseToContract(ctx, blsAggServiceResp)
+		}
+	}
+}
+
+// handleEventUpdate acts on the JobCreated and TaskCreated events that were just confirmed, and
+// undoes what was done for the ones a reorg removed.
+func (agg *Aggregator) handleEventUpdate(ctx context.Context, update reorg.Update[any]) {
+	if update.Reorged {
+		agg.logger.Warn("Chain reorganization detected", "forkPoint", update.ForkPoint, "removedEvents", len(update.Removed))
+	}
+	for _, removed := range update.Removed {
+		switch event := removed.Value.(type) {
+		case *jobmanager.ContractKeeperNetworkJobManagerJobCreated:
+			agg.logger.Warn("JobCreated event removed by a reorg", "jobId", event.JobId, "blockNumber", event.Raw.BlockNumber)
+			agg.jobsMu.Lock()
+			delete(agg.jobs, event.JobId)
+			agg.jobsMu.Unlock()
+		case *taskmanager.ContractKeeperNetworkTaskManagerTaskCreated:
+			agg.logger.Warn("TaskCreated event removed by a reorg", "taskId", event.TaskId, "blockNumber", event.Raw.BlockNumber)
+			agg.removeTask(event.TaskId)
 		}
 	}
+	for _, confirmed := range update.Confirmed {
+		switch event := confirmed.Value.(type) {
+		case *jobmanager.ContractKeeperNetworkJobManagerJobCreated:
+			if _, err := agg.getJob(ctx, event.JobId); err != nil {
+				agg.logger.Error("Failed to fetch new job", "jobId", event.JobId, "err", err)
+			}
+		case *taskmanager.ContractKeeperNetworkTaskManagerTaskCreated:
+			// we log the errors inside initializeTask() so here we just continue to the next event
+			_ = agg.initializeTask(ctx, event)
+		}
+	}
+}
+
+// getJob returns the job from the cache, reading it from KeeperNetworkJobManager on a miss.
+func (agg *Aggregator) getJob(ctx context.Context, jobId uint32) (chainio.Job, error) {
+	agg.jobsMu.RLock()
+	job, ok := agg.jobs[jobId]
+	agg.jobsMu.RUnlock()
+	if ok {
+		return job, nil
+	}
+	job, err := agg.avsReader.GetJob(ctx, jobId)
+	if err != nil {
+		return chainio.Job{}, err
+	}
+	agg.jobsMu.Lock()
+	agg.jobs[jobId] = job
+	agg.jobsMu.Unlock()
+	return job, nil
+}
+
+// initializeTask starts bls aggregation for a new task, with the quorums and threshold of its job.
+func (agg *Aggregator) initializeTask(ctx context.Context, newTaskCreatedLog *taskmanager.ContractKeeperNetworkTaskManagerTaskCreated) error {
+	job, err := agg.getJob(ctx, newTaskCreatedLog.JobId)
+	if err != nil {
+		agg.logger.Error("Failed to fetch job of new task", "taskId", newTaskCreatedLog.TaskId, "jobId", newTaskCreatedLog.JobId, "err", err)
+		return err
+	}
+	if len(job.QuorumNumbers) == 0 || job.QuorumThresholdPercentage == 0 {
+		err := fmt.Errorf("job %d has no quorums or a zero quorum threshold", job.JobId)
+		agg.logger.Error("Cannot aggregate responses for task", "taskId", newTaskCreatedLog.TaskId, "err", err)
+		return err
+	}
+
+	task := types.Task{
+		TaskId:                    newTaskCreatedLog.TaskId,
+		JobId:                     newTaskCreatedLog.JobId,
+		TaskType:                  newTaskCreatedLog.TaskType,
+		TaskCreatedBlock:          uint32(newTaskCreatedLog.Raw.BlockNumber),
+		QuorumNumbers:             job.QuorumNumbers,
+		QuorumThresholdPercentage: job.QuorumThresholdPercentage,
+	}
+	quorumThresholdPercentages := make(sdktypes.QuorumThresholdPercentages, len(task.QuorumNumbers))
+	for i := range quorumThresholdPercentages {
+		quorumThresholdPercentages[i] = task.QuorumThresholdPercentage
+	}
+
+	agg.tasksMu.Lock()
+	if removed, ok := agg.removedTasks[task.TaskId]; ok {
+		// included again after a reorg, see below
+		task.TaskCreatedBlock = removed.TaskCreatedBlock
+		delete(agg.removedTasks, task.TaskId)
+	} else if existing, ok := agg.tasks[task.TaskId]; ok {
+		task.TaskCreatedBlock = existing.TaskCreatedBlock
+	}
+	agg.tasks[task.TaskId] = task
+	agg.tasksMu.Unlock()
+
+	// TODO(samlaf): we use seconds for now, but we should ideally pass a blocknumber to the blsAggregationService
+	// and it should monitor the chain and only expire the task aggregation once the chain has reached that block number.
+	taskTimeToExpiry := taskChallengeWindowBlock * blockTimeSeconds
+	err = agg.blsAggregationService.InitializeNewTask(task.TaskId, task.TaskCreatedBlock, task.QuorumNumbers, quorumThresholdPercentages, taskTimeToExpiry)
+	if err != nil && err.Error() == blsagg.TaskAlreadyInitializedErrorFn(task.TaskId).Error() {
+		// the task was removed by a reorg and included again, while the bls aggregation service,
+		// which cannot stop a task, still aggregates it. The aggregation goes on from the original
+		// task's creation block, with the responses and signatures that are kept and stored for it.
+		agg.logger.Info("Task included again after a reorg is still being aggregated", "taskId", task.TaskId)
+		return nil
+	} else if err != nil {
+		agg.logger.Error("Failed to initialize new task", "taskId", task.TaskId, "err", err)
+		return err
+	}
+	err = agg.store.PutTask(store.TaskRecord{Task: task, ExpiresAt: time.Now().Add(taskTimeToExpiry)})
+	if err != nil {
+		// aggregation goes on, but the task will not survive a restart
+		agg.logger.Error("Failed to store new task", "taskId", task.TaskId, "err", err)
+	}
+	return nil
+}
+
+// removeTask forgets a task whose TaskCreated event a reorg removed, so that responses to it are
+// turned down. The bls aggregation service cannot stop aggregating it, so its responses and
+// signatures are kept until the aggregation ends, in case the new chain includes it again. Its
+// aggregated response is then dropped, see dropRemovedTask.
+func (agg *Aggregator) removeTask(taskIndex types.TaskIndex) {
+	agg.tasksMu.Lock()
+	if task, ok := agg.tasks[taskIndex]; ok {
+		agg.removedTasks[taskIndex] = task
+		delete(agg.tasks, taskIndex)
+	}
+	agg.tasksMu.Unlock()
+}
+
+// dropRemovedTask forgets the responses and signatures of a task a reorg removed, once the bls
+// aggregation service is done with it.
+func (agg *Aggregator) dropRemovedTask(taskIndex types.TaskIndex) {
+	agg.tasksMu.Lock()
+	delete(agg.removedTasks, taskIndex)
+	agg.tasksMu.Unlock()
+	if err := agg.store.DeleteTask(taskIndex); err != nil {
+		agg.logger.Error("Failed to delete removed task from the store", "taskId", taskIndex, "err", err)
+	}
+	agg.forgetTaskResponses(taskIndex)
 }
 
-func (agg *Aggregator) sendAggregatedResponseToContract(blsAggServiceResp blsagg.BlsAggregationServiceResponse) {
-	// TODO: check if blsAggServiceResp contains an err
-	if blsAggServiceResp.Err != nil {
-		agg.logger.Error("BlsAggregationServiceResponse contains an error", "err", blsAggServiceResp.Err)
-		// panicing to help with debugging (fail fast), but we shouldn't panic if we run this in production
-		panic(blsAggServiceResp.Err)
-	}
-	nonSignerPubkeys := []cstaskmanager.BN254G1Point{}
-	for _, nonSignerPubkey := range blsAggServiceResp.NonSignersPubkeysG1 {
-		nonSignerPubkeys = append(nonSignerPubkeys, core.ConvertToBN254G1Point(nonSignerPubkey))
-	}
-	quorumApks := []cstaskmanager.BN254G1Point{}
-	for _, quorumApk := range blsAggServiceResp.QuorumApksG1 {
-		quorumApks = append(quorumApks, core.ConvertToBN254G1Point(quorumApk))
-	}
-	nonSignerStakesAndSignature := cstaskmanager.IBLSSignatureCheckerNonSignerStakesAndSignature{
-		NonSignerPubkeys:             nonSignerPubkeys,
-		QuorumApks:                   quorumApks,
-		ApkG2:                        core.ConvertToBN254G2Point(blsAggServiceResp.SignersApkG2),
-		Sigma:                        core.ConvertToBN254G1Point(blsAggServiceResp.SignersAggSigG1.G1Point),
-		NonSignerQuorumBitmapIndices: blsAggServiceResp.NonSignerQuorumBitmapIndices,
-		QuorumApkIndices:             blsAggServiceResp.QuorumApkIndices,
-		TotalStakeIndices:            blsAggServiceResp.TotalStakeIndices,
-		NonSignerStakeIndices:        blsAggServiceResp.NonSignerStakeIndices,
-	}
-
-	agg.logger.Info("Threshold reached. Sending aggregated response onchain.",
-		"taskIndex", blsAggServiceResp.TaskIndex,
-	)
+func (agg *Aggregator) hasTask(taskIndex types.TaskIndex) bool {
 	agg.tasksMu.RLock()
-	task := agg.tasks[blsAggServiceResp.TaskIndex]
-	agg.tasksMu.RUnlock()
-	agg.taskResponsesMu.RLock()
-	taskResponse := agg.taskResponses[blsAggServiceResp.TaskIndex][blsAggServiceResp.TaskResponseDigest]
-	agg.taskResponsesMu.RUnlock()
-	_, err := agg.avsWriter.SendAggregatedResponse(context.Background(), task, taskResponse, nonSignerStakesAndSignature)
+	defer agg.tasksMu.RUnlock()
+	_, ok := agg.tasks[taskIndex]
+	return ok
+}
+
+// recoverTasks re-initializes bls aggregation for the stored tasks that have not expired yet,
+// and drops the expired ones from the store. It returns the recovered tasks, whose signatures
+// still have to be replayed.
+func (agg *Aggregator) recoverTasks() ([]store.StoredTask, error) {
+	storedTasks, err := agg.store.Tasks()
 	if err != nil {
-		agg.logger.Error("Aggregator failed to respond to task", "err", err)
+		agg.logger.Error("Failed to read stored tasks", "err", err)
+		return nil, err
+	}
+	recoveredTasks := make([]store.StoredTask, 0, len(storedTasks))
+	for _, storedTask := range storedTasks {
+		task := storedTask.Task
+		timeToExpiry := time.Until(storedTask.ExpiresAt)
+		if timeToExpiry <= 0 {
+			agg.logger.Info("Dropping stored task that expired while the aggregator was down", "taskId", task.TaskId)
+			if err := agg.store.DeleteTask(task.TaskId); err != nil {
+				agg.logger.Error("Failed to delete expired task", "taskId", task.TaskId, "err", err)
+			}
+			continue
+		}
+
+		agg.tasksMu.Lock()
+		agg.tasks[task.TaskId] = task
+		agg.tasksMu.Unlock()
+		agg.taskResponsesMu.Lock()
+		agg.taskResponses[task.TaskId] = storedTask.TaskResponses
+		agg.taskResponsesMu.Unlock()
+
+		quorumThresholdPercentages := make(sdktypes.QuorumThresholdPercentages, len(task.QuorumNumbers))
+		for i := range quorumThresholdPercentages {
+			quorumThresholdPercentages[i] = task.QuorumThresholdPercentage
+		}
+		err := agg.blsAggregationService.InitializeNewTask(task.TaskId, task.TaskCreatedBlock, task.QuorumNumbers, quorumThresholdPercentages, timeToExpiry)
+		if err != nil {
+			agg.logger.Error("Failed to re-initialize stored task", "taskId", task.TaskId, "err", err)
+			continue
+		}
+		agg.logger.Info("Recovered stored task", "taskId", task.TaskId, "signatures", len(storedTask.Signatures), "timeToExpiry", timeToExpiry)
+		recoveredTasks = append(recoveredTasks, storedTask)
 	}
+	return recoveredTasks, nil
 }
 
+// replaySignatures hands the stored signatures of recovered tasks back to the bls aggregation service.
+func (agg *Aggregator) replaySignatures(ctx context.Context, recoveredTasks []store.StoredTask) {
+	for _, recoveredTask := range recoveredTasks {
+		for _, signature := range recoveredTask.Signatures {
+			signature := signature
+			err := agg.blsAggregationService.ProcessNewSignature(
+				ctx, recoveredTask.Task.TaskId, signature.TaskResponseDigest,
+				&signature.BlsSignature, signature.OperatorId,
+			)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				// most likely an earlier replayed signature already completed the task
+				agg.logger.Warn("Failed to replay stored signature", "taskId", recoveredTask.Task.TaskId,
+					"operatorId", fmt.Sprintf("%x", signature.OperatorId), "err", err)
+			}
+		}
+	}
+}
+
+// hashOfNonSigners is the signatoryRecordHash BLSSignatureChecker.checkSignatures computes:
+// keccak256(abi.encodePacked(referenceBlockNumber, nonSignerPubkeyHashes)), where each pubkey
+// hash is BN254.hashG1Point, keccak256(abi.encodePacked(X, Y)).
+func hashOfNonSigners(referenceBlockNumber uint32, nonSignerPubkeys []*bls.G1Point) [32]byte {
+	packed := binary.BigEndian.AppendUint32(nil, referenceBlockNumber)
+	for _, pubkey := range nonSignerPubkeys {
+		x, y := pubkey.X.Bytes(), pubkey.Y.Bytes()
+		packed = append(packed, crypto.Keccak256(x[:], y[:])...)
+	}
+	return crypto.Keccak256Hash(packed)
+}
@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type Metrics interface {
+	metrics.Metrics
+	TaskResponded()
+	TaskFailed(reason string)
+	ResponseSubmissionRetried()
+	// the health of the chain subscriptions, see chainio.SubscriptionMetrics
+	SubscriptionTransport(subscription string, transport string)
+	SubscriptionFailed(subscription string)
+	SubscriptionReconnected(subscription string)
+	LogsFetched(subscription string, count int)
+}
+
+type AggregatorMetrics struct {
+	metrics.Metrics
+	tasksResponded             prometheus.Counter
+	tasksFailed                *prometheus.CounterVec
+	responseSubmissionsRetried prometheus.Counter
+	subscriptionTransport      *prometheus.GaugeVec
+	subscriptionFailures       *prometheus.CounterVec
+	subscriptionReconnects     *prometheus.CounterVec
+	logsFetched                *prometheus.CounterVec
+}
+
+// the transports a subscription can use, the values of the subscription_transport metric's label
+var subscriptionTransports = []string{"websocket", "polling"}
+
+const aggregatorNamespace = "aggregator"
+
+func NewAggregatorMetrics(eigenMetrics metrics.Metrics, reg prometheus.Registerer) *AggregatorMetrics {
+	return &AggregatorMetrics{
+		Metrics: eigenMetrics,
+		tasksResponded: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: aggregatorNamespace,
+				Name:      "tasks_responded",
+				Help:      "The number of aggregated task responses sent to the task manager contract",
+			}),
+		tasksFailed: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: aggregatorNamespace,
+				Name:      "tasks_failed",
+				Help:      "The number of tasks the aggregator could not respond to, by reason",
+			},
+			[]string{"reason"},
+		),
+		responseSubmissionsRetried: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: aggregatorNamespace,
+				Name:      "response_submissions_retried",
+				Help:      "The number of times sending an aggregated task response was retried after a transient failure",
+			}),
+		subscriptionTransport: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: aggregatorNamespace,
+				Name:      "subscription_transport",
+				Help:      "1 for the transport a chain subscription currently uses, 0 for the others",
+			},
+			[]string{"subscription", "transport"},
+		),
+		subscriptionFailures: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: aggregatorNamespace,
+				Name:      "subscription_failures",
+				Help:      "The number of times subscribing over websocket failed or a subscription broke",
+			},
+			[]string{"subscription"},
+		),
+		subscriptionReconnects: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: aggregatorNamespace,
+				Name:      "subscription_reconnects",
+				Help:      "The number of times a chain subscription came back over websocket after failing",
+			},
+			[]string{"subscription"},
+		),
+		logsFetched: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: aggregatorNamespace,
+				Name:      "logs_fetched",
+				Help:      "The number of logs fetched with eth_getLogs while polling or filling the gap after a reconnect",
+			},
+			[]string{"subscription"},
+		),
+	}
+}
+
+func (m *AggregatorMetrics) TaskResponded() {
+	m.tasksResponded.Inc()
+}
+
+func (m *AggregatorMetrics) TaskFailed(reason string) {
+	m.tasksFailed.WithLabelValues(reason).Inc()
+}
+
+func (m *AggregatorMetrics) ResponseSubmissionRetried() {
+	m.responseSubmissionsRetried.Inc()
+}
+
+func (m *AggregatorMetrics) SubscriptionTransport(subscription string, transport string) {
+	for _, t := range subscriptionTransports {
+		value := 0.0
+		if t == transport {
+			value = 1
+		}
+		m.subscriptionTransport.WithLabelValues(subscription, t).Set(value)
+	}
+}
+
+func (m *AggregatorMetrics) SubscriptionFailed(subscription string) {
+	m.subscriptionFailures.WithLabelValues(subscription).Inc()
+}
+
+func (m *AggregatorMetrics) SubscriptionReconnected(subscription string) {
+	m.subscriptionReconnects.WithLabelValues(subscription).Inc()
+}
+
+func (m *AggregatorMetrics) LogsFetched(subscription string, count int) {
+	m.logsFetched.WithLabelValues(subscription).Add(float64(count))
+}
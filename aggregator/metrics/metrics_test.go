@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewAggregatorMetrics(&metrics.EigenMetrics{}, reg)
+
+	m.TaskResponded()
+	if testutil.ToFloat64(m.tasksResponded) != 1 {
+		t.Errorf("tasksResponded should be 1, got %f", testutil.ToFloat64(m.tasksResponded))
+	}
+
+	m.TaskFailed("expired")
+	m.TaskFailed("expired")
+	m.TaskFailed("tx_reverted")
+	if testutil.ToFloat64(m.tasksFailed.WithLabelValues("expired")) != 2 {
+		t.Errorf("tasksFailed{reason=expired} should be 2, got %f", testutil.ToFloat64(m.tasksFailed.WithLabelValues("expired")))
+	}
+	if testutil.ToFloat64(m.tasksFailed.WithLabelValues("tx_reverted")) != 1 {
+		t.Errorf("tasksFailed{reason=tx_reverted} should be 1, got %f", testutil.ToFloat64(m.tasksFailed.WithLabelValues("tx_reverted")))
+	}
+
+	m.ResponseSubmissionRetried()
+	if testutil.ToFloat64(m.responseSubmissionsRetried) != 1 {
+		t.Errorf("responseSubmissionsRetried should be 1, got %f", testutil.ToFloat64(m.responseSubmissionsRetried))
+	}
+
+	m.SubscriptionTransport("TaskCreated", "websocket")
+	m.SubscriptionTransport("TaskCreated", "polling")
+	if testutil.ToFloat64(m.subscriptionTransport.WithLabelValues("TaskCreated", "polling")) != 1 ||
+		testutil.ToFloat64(m.subscriptionTransport.WithLabelValues("TaskCreated", "websocket")) != 0 {
+		t.Errorf("subscriptionTransport{subscription=TaskCreated} should only be 1 for polling")
+	}
+}
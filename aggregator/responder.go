@@ -0,0 +1,257 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
+	sdktypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/Layr-Labs/incredible-squaring-avs/aggregator/types"
+	"github.com/Layr-Labs/incredible-squaring-avs/core"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	taskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/KeeperNetworkTaskManager"
+)
+
+const (
+	// sending an aggregated response is attempted this many times before the task is failed.
+	// The wait between attempts starts at respondToTaskInitialBackoff and doubles up to respondToTaskMaxBackoff.
+	respondToTaskMaxAttempts    = 5
+	respondToTaskInitialBackoff = 2 * time.Second
+	respondToTaskMaxBackoff     = 30 * time.Second
+
+	// the onchain status of a task the aggregator gave up on is failedTaskStatusPrefix followed by the reason
+	failedTaskStatusPrefix = "failed:"
+)
+
+var errTxReverted = errors.New("transaction reverted")
+
+// sendAggregatedResponseToContract sends the task response that reached quorum through respondToTask,
+// retrying with backoff when sending fails for a transient reason. The task is failed if the
+// transaction reverts or the attempts run out.
+func (agg *Aggregator) sendAggregatedResponseToContract(ctx context.Context, blsAggServiceResp blsagg.BlsAggregationServiceResponse) {
+	nonSignerPubkeys := []taskmanager.BN254G1Point{}
+	for _, nonSignerPubkey := range blsAggServiceResp.NonSignersPubkeysG1 {
+		nonSignerPubkeys = append(nonSignerPubkeys, core.ConvertToBN254G1Point(nonSignerPubkey))
+	}
+
+	agg.logger.Info("Threshold reached. Sending aggregated response onchain.",
+		"taskIndex", blsAggServiceResp.TaskIndex,
+	)
+	agg.tasksMu.RLock()
+	task, ok := agg.tasks[blsAggServiceResp.TaskIndex]
+	agg.tasksMu.RUnlock()
+	if !ok {
+		agg.logger.Warn("Dropping aggregated response of a task removed by a reorg", "taskIndex", blsAggServiceResp.TaskIndex)
+		agg.dropRemovedTask(blsAggServiceResp.TaskIndex)
+		return
+	}
+	agg.taskResponsesMu.RLock()
+	taskResponse, ok := agg.taskResponses[blsAggServiceResp.TaskIndex][blsAggServiceResp.TaskResponseDigest]
+	agg.taskResponsesMu.RUnlock()
+	if !ok {
+		// sending a zero task response would attest to a result no operator signed
+		agg.failTask(ctx, task.TaskId, types.TaskFailureAggregation,
+			fmt.Errorf("no task response with the aggregated digest %x", blsAggServiceResp.TaskResponseDigest))
+		return
+	}
+
+	backoff := respondToTaskInitialBackoff
+	var err error
+	for attempt := 1; attempt <= respondToTaskMaxAttempts; attempt++ {
+		err = agg.respondToTask(ctx, task, taskResponse, blsAggServiceResp, nonSignerPubkeys)
+		if err == nil {
+			agg.logger.Info("Aggregated response sent onchain", "taskIndex", task.TaskId)
+			agg.metrics.TaskResponded()
+			if err := agg.store.DeleteTask(task.TaskId); err != nil {
+				agg.logger.Error("Failed to delete responded task from the store", "taskId", task.TaskId, "err", err)
+			}
+			agg.forgetTaskResponses(task.TaskId)
+			return
+		}
+		if errors.Is(err, errTxReverted) {
+			agg.failTask(ctx, task.TaskId, types.TaskFailureTxReverted, err)
+			return
+		}
+		if attempt == respondToTaskMaxAttempts {
+			break
+		}
+		agg.logger.Warn("Aggregator failed to respond to task. Retrying...",
+			"taskIndex", task.TaskId, "attempt", attempt, "retryIn", backoff, "err", err)
+		agg.metrics.ResponseSubmissionRetried()
+		select {
+		case <-ctx.Done():
+			// the task stays in the store, so it is aggregated and sent again when the aggregator restarts
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, respondToTaskMaxBackoff)
+	}
+	agg.failTask(ctx, task.TaskId, types.TaskFailureSubmission,
+		fmt.Errorf("gave up after %d attempts: %w", respondToTaskMaxAttempts, err))
+}
+
+// respondToTask makes a single attempt at sending the aggregated response. It returns an error
+// wrapping errTxReverted if retrying cannot help.
+func (agg *Aggregator) respondToTask(
+	ctx context.Context,
+	task types.Task,
+	taskResponse core.TaskResponse,
+	blsAggServiceResp blsagg.BlsAggregationServiceResponse,
+	nonSignerPubkeys []taskmanager.BN254G1Point,
+) error {
+	currentBlock, err := agg.ethClient.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+	taskResponseMetadata := taskmanager.IKeeperNetworkTaskManagerTaskResponseMetadata{
+		TaskResponsedBlock: new(big.Int).SetUint64(currentBlock),
+		HashOfNonSigners:   hashOfNonSigners(task.TaskCreatedBlock, blsAggServiceResp.NonSignersPubkeysG1),
+	}
+
+	agg.txMu.Lock()
+	defer agg.txMu.Unlock()
+	receipt, err := agg.avsWriter.SendAggregatedResponse(ctx, task.TaskId, core.ConvertToTaskManagerTaskResponse(&taskResponse), taskResponseMetadata, nonSignerPubkeys)
+	if err != nil {
+		// gas estimation fails with the revert reason when the call would revert
+		if strings.Contains(err.Error(), vm.ErrExecutionReverted.Error()) {
+			return fmt.Errorf("%w: %v", errTxReverted, err)
+		}
+		return err
+	}
+	if receipt.Status != gethtypes.ReceiptStatusSuccessful {
+		return fmt.Errorf("%w: tx %s", errTxReverted, receipt.TxHash.Hex())
+	}
+	return nil
+}
+
+// recordUpkeep records the upkeep response of a task onchain through recordUpkeep, retrying with
+// backoff like sendAggregatedResponseToContract. The upkeep was carried out whether or not it is
+// recorded, so giving up only loses the record.
+func (agg *Aggregator) recordUpkeep(ctx context.Context, upkeepResponse core.TaskResponse, operatorId sdktypes.OperatorId) {
+	taskIndex := upkeepResponse.ReferenceTaskId
+	backoff := respondToTaskInitialBackoff
+	var err error
+	for attempt := 1; attempt <= respondToTaskMaxAttempts; attempt++ {
+		err = agg.sendUpkeepResponse(ctx, upkeepResponse, operatorId)
+		if err == nil {
+			agg.logger.Info("Upkeep recorded onchain", "taskIndex", taskIndex, "txHash", fmt.Sprintf("%x", upkeepResponse.TxHash))
+			return
+		}
+		if errors.Is(err, errTxReverted) || attempt == respondToTaskMaxAttempts {
+			break
+		}
+		agg.logger.Warn("Aggregator failed to record upkeep. Retrying...",
+			"taskIndex", taskIndex, "attempt", attempt, "retryIn", backoff, "err", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, respondToTaskMaxBackoff)
+	}
+	agg.logger.Error("Aggregator failed to record upkeep", "taskIndex", taskIndex, "err", err)
+}
+
+// sendUpkeepResponse makes a single attempt at recording an upkeep response. It returns an error
+// wrapping errTxReverted if retrying cannot help.
+func (agg *Aggregator) sendUpkeepResponse(ctx context.Context, upkeepResponse core.TaskResponse, operatorId sdktypes.OperatorId) error {
+	agg.txMu.Lock()
+	defer agg.txMu.Unlock()
+	receipt, err := agg.avsWriter.RecordUpkeep(ctx, upkeepResponse.ReferenceTaskId, core.ConvertToTaskManagerTaskResponse(&upkeepResponse), operatorId)
+	if err != nil {
+		if strings.Contains(err.Error(), vm.ErrExecutionReverted.Error()) {
+			return fmt.Errorf("%w: %v", errTxReverted, err)
+		}
+		return err
+	}
+	if receipt.Status != gethtypes.ReceiptStatusSuccessful {
+		return fmt.Errorf("%w: tx %s", errTxReverted, receipt.TxHash.Hex())
+	}
+	return nil
+}
+
+// handleAggregationError fails the task an error from the bls aggregation service is about.
+func (agg *Aggregator) handleAggregationError(ctx context.Context, err error) {
+	taskIndex, expired, ok := parseAggregationError(err)
+	if !ok {
+		// the task goroutine has stopped, so the task is left to expire in the store
+		agg.logger.Error("Bls aggregation service returned an error for an unknown task", "err", err)
+		agg.metrics.TaskFailed(string(types.TaskFailureAggregation))
+		return
+	}
+	if !agg.hasTask(taskIndex) {
+		agg.logger.Info("Ignoring bls aggregation error for a task removed by a reorg", "taskIndex", taskIndex, "err", err)
+		agg.dropRemovedTask(taskIndex)
+		return
+	}
+	if !expired {
+		agg.failTask(ctx, taskIndex, types.TaskFailureAggregation, err)
+		return
+	}
+	agg.taskResponsesMu.RLock()
+	responded := len(agg.taskResponses[taskIndex]) > 0
+	agg.taskResponsesMu.RUnlock()
+	if responded {
+		agg.failTask(ctx, taskIndex, types.TaskFailureQuorumNotMet, err)
+	} else {
+		agg.failTask(ctx, taskIndex, types.TaskFailureExpired, err)
+	}
+}
+
+// parseAggregationError recovers the task index from the errors the bls aggregation service sends
+// on its response channel, which only carry it in their message, and reports whether the task expired.
+func parseAggregationError(err error) (taskIndex types.TaskIndex, expired bool, ok bool) {
+	msg := err.Error()
+	if _, scanErr := fmt.Sscanf(msg, "task %d expired", &taskIndex); scanErr == nil && msg == blsagg.TaskExpiredErrorFn(taskIndex).Error() {
+		return taskIndex, true, true
+	}
+	if _, scanErr := fmt.Sscanf(msg, "Failed to initialize task %d:", &taskIndex); scanErr == nil {
+		return taskIndex, false, true
+	}
+	return 0, false, false
+}
+
+// failTask records that the aggregator gave up on a task. The task stays in agg.tasks so that
+// keepers still responding to it are told it is closed.
+func (agg *Aggregator) failTask(ctx context.Context, taskIndex types.TaskIndex, reason types.TaskFailureReason, cause error) {
+	agg.logger.Error("Aggregator failed task", "taskIndex", taskIndex, "reason", reason, "err", cause)
+	agg.metrics.TaskFailed(string(reason))
+
+	agg.tasksMu.RLock()
+	task, ok := agg.tasks[taskIndex]
+	agg.tasksMu.RUnlock()
+	if !ok {
+		task = types.Task{TaskId: taskIndex}
+	}
+	err := agg.store.PutFailedTask(types.FailedTask{
+		Task:     task,
+		Reason:   reason,
+		Detail:   cause.Error(),
+		FailedAt: time.Now(),
+	})
+	if err != nil {
+		agg.logger.Error("Failed to record failed task", "taskIndex", taskIndex, "err", err)
+	}
+	agg.forgetTaskResponses(taskIndex)
+
+	if !agg.updateTaskStatusOnFailure {
+		return
+	}
+	agg.txMu.Lock()
+	defer agg.txMu.Unlock()
+	if _, err := agg.avsWriter.UpdateTaskStatus(ctx, taskIndex, failedTaskStatusPrefix+string(reason)); err != nil {
+		agg.logger.Error("Failed to mark task failed onchain", "taskIndex", taskIndex, "err", err)
+	}
+}
+
+func (agg *Aggregator) forgetTaskResponses(taskIndex types.TaskIndex) {
+	agg.taskResponsesMu.Lock()
+	delete(agg.taskResponses, taskIndex)
+	agg.taskResponsesMu.Unlock()
+}
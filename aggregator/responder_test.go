@@ -0,0 +1,31 @@
+package aggregator
+
+import (
+	"errors"
+	"testing"
+
+	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
+)
+
+func TestParseAggregationError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		taskIndex uint32
+		expired   bool
+		ok        bool
+	}{
+		{"expired", blsagg.TaskExpiredErrorFn(42), 42, true, true},
+		{"initialization", blsagg.TaskInitializationErrorFn(errors.New("rpc down"), 7), 7, false, true},
+		{"unattributed", errors.New("Failed to get check signatures indices"), 0, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskIndex, expired, ok := parseAggregationError(tt.err)
+			if taskIndex != tt.taskIndex || expired != tt.expired || ok != tt.ok {
+				t.Errorf("parseAggregationError(%q) = (%d, %v, %v), want (%d, %v, %v)",
+					tt.err, taskIndex, expired, ok, tt.taskIndex, tt.expired, tt.ok)
+			}
+		})
+	}
+}
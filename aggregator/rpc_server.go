@@ -0,0 +1,305 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/rpc"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
+	sdktypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/Layr-Labs/incredible-squaring-avs/aggregator/store"
+	"github.com/Layr-Labs/incredible-squaring-avs/aggregator/types"
+	"github.com/Layr-Labs/incredible-squaring-avs/core"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Errors returned to keepers by ProcessSignedTaskResponse. net/rpc only carries
+// the error string across the wire, so the error class is encoded as a status
+// prefix: "400." errors are permanent and resending the same response will
+// fail again, "500." errors are transient and the response should be retried.
+// Use IsRetryableError to tell them apart on the client.
+var (
+	TaskNotFoundError500                    = errors.New("500. Task not found")
+	OperatorAlreadyRespondedError400        = errors.New("400. Operator already sent a different response to this task")
+	TaskClosedError400                      = errors.New("400. Task no longer accepts responses")
+	InvalidTaskResponseError400             = errors.New("400. Invalid task response")
+	OperatorNotPartOfTaskQuorum400          = errors.New("400. Operator not part of quorum")
+	SignatureVerificationFailed400          = errors.New("400. Signature verification failed")
+	TaskResponseDigestNotFoundError500      = errors.New("500. Failed to get task response digest")
+	CallToGetOperatorsAvsStateFailed500     = errors.New("500. Failed to get operators avs state")
+	UnknownErrorWhileVerifyingSignature500  = errors.New("500. Failed to verify signature")
+	UnknownErrorWhileProcessingSignature500 = errors.New("500. Failed to process signature")
+	UpkeepReceiptNotFoundError500           = errors.New("500. Upkeep transaction receipt not found")
+)
+
+const (
+	permanentErrorPrefix = "400."
+
+	rpcServerShutdownTimeout = 5 * time.Second
+)
+
+// SignedTaskResponse is what keepers send to Aggregator.ProcessSignedTaskResponse.
+type SignedTaskResponse struct {
+	TaskResponse core.TaskResponse
+	BlsSignature bls.Signature
+	OperatorId   sdktypes.OperatorId
+}
+
+// IsRetryableError reports whether a failed call to Aggregator.ProcessSignedTaskResponse
+// may succeed if the same signed task response is sent again. Errors that did
+// not come from the aggregator itself, such as connection failures, are retryable.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var serverErr rpc.ServerError
+	if errors.As(err, &serverErr) {
+		return !strings.HasPrefix(string(serverErr), permanentErrorPrefix)
+	}
+	return !strings.HasPrefix(err.Error(), permanentErrorPrefix)
+}
+
+func (agg *Aggregator) startServer(ctx context.Context) error {
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.Register(agg); err != nil {
+		agg.logger.Fatal("Format of service Aggregator isn't correct. ", "err", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(rpc.DefaultRPCPath, rpcServer)
+	server := &http.Server{Addr: agg.serverIpPortAddr, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), rpcServerShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			agg.logger.Error("Failed to shut down aggregator rpc server", "err", err)
+		}
+	}()
+
+	agg.logger.Info("Aggregator rpc server listening", "address", agg.serverIpPortAddr)
+	err := server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		agg.logger.Fatal("ListenAndServe", "err", err)
+	}
+	return nil
+}
+
+// ProcessSignedTaskResponse is the rpc method keepers call to submit their signed task responses.
+// The digest is recomputed from the task response rather than trusted from the keeper, and the
+// operator and its signature are checked against the operator set at the task's creation block
+// before the signature is handed to the bls aggregation service.
+func (agg *Aggregator) ProcessSignedTaskResponse(signedTaskResponse *SignedTaskResponse, reply *bool) error {
+	agg.logger.Infof("Received signed task response: %#v", signedTaskResponse)
+	taskResponse := signedTaskResponse.TaskResponse
+	taskIndex := taskResponse.ReferenceTaskId
+
+	agg.tasksMu.RLock()
+	task, ok := agg.tasks[taskIndex]
+	agg.tasksMu.RUnlock()
+	if !ok {
+		// the keeper may have seen the task before the aggregator did
+		return fmt.Errorf("%w: task %d", TaskNotFoundError500, taskIndex)
+	}
+	if err := validateTaskResponse(task, &taskResponse); err != nil {
+		return fmt.Errorf("%w: %v", InvalidTaskResponseError400, err)
+	}
+	if taskResponse.IsUpkeepResponse() {
+		return fmt.Errorf("%w: upkeep responses go to ProcessSignedUpkeepResponse", InvalidTaskResponseError400)
+	}
+
+	taskResponseDigest, err := core.GetTaskResponseDigest(agg.taskResponseDomain, &taskResponse)
+	if err != nil {
+		agg.logger.Error("Failed to get task response digest", "err", err)
+		return TaskResponseDigestNotFoundError500
+	}
+
+	if err := agg.verifyOperatorSignature(task.TaskCreatedBlock, task.QuorumNumbers, taskResponseDigest, signedTaskResponse); err != nil {
+		agg.logger.Warn("Rejected signed task response", "taskIndex", taskIndex, "operatorId", fmt.Sprintf("%x", signedTaskResponse.OperatorId), "err", err)
+		return err
+	}
+
+	// the signature is stored once it is aggregated, so it is replayed if the aggregator restarts
+	// before the task completes. It also lets a keeper safely resend a response whose reply it missed,
+	// since the bls aggregation service would count the operator's stake twice. A signature that
+	// fails to aggregate is not stored, so the keeper's retry aggregates it again. signaturesMu is
+	// held until the signature is stored, so that concurrent resends are not aggregated twice.
+	agg.signaturesMu.Lock()
+	defer agg.signaturesMu.Unlock()
+	storedSignature, found, err := agg.store.GetSignature(taskIndex, signedTaskResponse.OperatorId)
+	if err != nil {
+		agg.logger.Error("Failed to read stored signature", "taskIndex", taskIndex, "err", err)
+		return fmt.Errorf("%w: %v", UnknownErrorWhileProcessingSignature500, err)
+	}
+	if found {
+		if storedSignature.TaskResponseDigest != taskResponseDigest {
+			return OperatorAlreadyRespondedError400
+		}
+		*reply = true
+		return nil
+	}
+
+	agg.taskResponsesMu.Lock()
+	if _, ok := agg.taskResponses[taskIndex]; !ok {
+		agg.taskResponses[taskIndex] = make(map[sdktypes.TaskResponseDigest]core.TaskResponse)
+	}
+	if _, ok := agg.taskResponses[taskIndex][taskResponseDigest]; !ok {
+		agg.taskResponses[taskIndex][taskResponseDigest] = taskResponse
+	}
+	agg.taskResponsesMu.Unlock()
+
+	err = agg.blsAggregationService.ProcessNewSignature(
+		context.Background(), taskIndex, taskResponseDigest,
+		&signedTaskResponse.BlsSignature, signedTaskResponse.OperatorId,
+	)
+	if err != nil {
+		return classifyProcessNewSignatureError(taskIndex, err)
+	}
+
+	err = agg.store.PutSignature(taskIndex, taskResponse, store.Signature{
+		OperatorId:         signedTaskResponse.OperatorId,
+		TaskResponseDigest: taskResponseDigest,
+		BlsSignature:       signedTaskResponse.BlsSignature,
+	})
+	if err != nil && !errors.Is(err, store.ErrTaskNotStored) {
+		// aggregated all the same, only a restart before the task completes loses it
+		agg.logger.Error("Failed to store signature", "taskIndex", taskIndex, "err", err)
+	}
+	// ErrTaskNotStored: the signature completed the task, which was responded to since
+	*reply = true
+	return nil
+}
+
+// ProcessSignedUpkeepResponse is the rpc method the operator an upkeep task is assigned to calls
+// once its upkeep transaction is included, with its task response signed again with the
+// transaction's hash and status (see core.TaskResponse.TxHash). The signature is checked like in
+// ProcessSignedTaskResponse and the status against the transaction's receipt, then the response is
+// recorded onchain through recordUpkeep. A task's upkeep is only recorded once.
+func (agg *Aggregator) ProcessSignedUpkeepResponse(signedUpkeepResponse *SignedTaskResponse, reply *bool) error {
+	agg.logger.Infof("Received signed upkeep response: %#v", signedUpkeepResponse)
+	upkeepResponse := signedUpkeepResponse.TaskResponse
+	taskIndex := upkeepResponse.ReferenceTaskId
+
+	agg.tasksMu.RLock()
+	task, ok := agg.tasks[taskIndex]
+	agg.tasksMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: task %d", TaskNotFoundError500, taskIndex)
+	}
+	if err := validateTaskResponse(task, &upkeepResponse); err != nil {
+		return fmt.Errorf("%w: %v", InvalidTaskResponseError400, err)
+	}
+	if !upkeepResponse.IsUpkeepResponse() || upkeepResponse.Status != core.TaskStatusSucceeded {
+		return fmt.Errorf("%w: not the response of a submitted upkeep", InvalidTaskResponseError400)
+	}
+
+	upkeepResponseDigest, err := core.GetTaskResponseDigest(agg.taskResponseDomain, &upkeepResponse)
+	if err != nil {
+		agg.logger.Error("Failed to get upkeep response digest", "err", err)
+		return TaskResponseDigestNotFoundError500
+	}
+	if err := agg.verifyOperatorSignature(task.TaskCreatedBlock, task.QuorumNumbers, upkeepResponseDigest, signedUpkeepResponse); err != nil {
+		agg.logger.Warn("Rejected signed upkeep response", "taskIndex", taskIndex, "operatorId", fmt.Sprintf("%x", signedUpkeepResponse.OperatorId), "err", err)
+		return err
+	}
+
+	receipt, err := agg.ethClient.TransactionReceipt(context.Background(), upkeepResponse.TxHash)
+	if err != nil {
+		// the aggregator's node may not have the block yet
+		return fmt.Errorf("%w: %v", UpkeepReceiptNotFoundError500, err)
+	}
+	if err := validateUpkeepReceipt(&upkeepResponse, receipt); err != nil {
+		return fmt.Errorf("%w: %v", InvalidTaskResponseError400, err)
+	}
+
+	agg.upkeepsMu.Lock()
+	if _, ok := agg.upkeeps[taskIndex]; ok {
+		agg.upkeepsMu.Unlock()
+		*reply = true
+		return nil
+	}
+	agg.upkeeps[taskIndex] = upkeepResponseDigest
+	agg.upkeepsMu.Unlock()
+
+	// sending can take several attempts, so the keeper is not kept waiting
+	go agg.recordUpkeep(context.Background(), upkeepResponse, signedUpkeepResponse.OperatorId)
+	*reply = true
+	return nil
+}
+
+// validateUpkeepReceipt checks that the upkeep response attests to the receipt of its transaction.
+func validateUpkeepReceipt(upkeepResponse *core.TaskResponse, receipt *gethtypes.Receipt) error {
+	txStatus := core.UpkeepTxReverted
+	if receipt.Status == gethtypes.ReceiptStatusSuccessful {
+		txStatus = core.UpkeepTxSucceeded
+	}
+	if upkeepResponse.TxStatus != txStatus {
+		return fmt.Errorf("transaction %s has status %d, not %d", receipt.TxHash.Hex(), txStatus, upkeepResponse.TxStatus)
+	}
+	return nil
+}
+
+func validateTaskResponse(task types.Task, taskResponse *core.TaskResponse) error {
+	if taskResponse.JobId != task.JobId {
+		return fmt.Errorf("task %d belongs to job %d, not %d", task.TaskId, task.JobId, taskResponse.JobId)
+	}
+	if taskResponse.Version != core.TaskResponseVersion {
+		return fmt.Errorf("unsupported version %d", taskResponse.Version)
+	}
+	switch taskResponse.Status {
+	case core.TaskStatusSucceeded, core.TaskStatusFailed, core.TaskStatusSkipped:
+	default:
+		return fmt.Errorf("unknown status %d", taskResponse.Status)
+	}
+	return nil
+}
+
+// verifyOperatorSignature checks that the operator was registered in the task's quorums when the
+// task was created, and that the signature is over the digest and was made with its G2 pubkey.
+func (agg *Aggregator) verifyOperatorSignature(
+	taskCreatedBlock uint32,
+	quorumNumbers sdktypes.QuorumNums,
+	taskResponseDigest sdktypes.TaskResponseDigest,
+	signedTaskResponse *SignedTaskResponse,
+) error {
+	operatorsAvsState, err := agg.avsRegistryService.GetOperatorsAvsStateAtBlock(context.Background(), quorumNumbers, taskCreatedBlock)
+	if err != nil {
+		agg.logger.Error("Failed to get operators avs state", "block", taskCreatedBlock, "err", err)
+		return CallToGetOperatorsAvsStateFailed500
+	}
+	operatorState, ok := operatorsAvsState[signedTaskResponse.OperatorId]
+	if !ok {
+		return OperatorNotPartOfTaskQuorum400
+	}
+	g2Pubkey := operatorState.OperatorInfo.Pubkeys.G2Pubkey
+	if g2Pubkey == nil {
+		return fmt.Errorf("%w: operator G2 pubkey not found", UnknownErrorWhileVerifyingSignature500)
+	}
+	verified, err := signedTaskResponse.BlsSignature.Verify(g2Pubkey, taskResponseDigest)
+	if err != nil {
+		return fmt.Errorf("%w: %v", UnknownErrorWhileVerifyingSignature500, err)
+	}
+	if !verified {
+		return SignatureVerificationFailed400
+	}
+	return nil
+}
+
+// classifyProcessNewSignatureError maps errors from the bls aggregation service, which are plain
+// formatted errors, onto the aggregator's typed errors.
+func classifyProcessNewSignatureError(taskIndex sdktypes.TaskIndex, err error) error {
+	switch {
+	case errors.Is(err, blsagg.IncorrectSignatureError):
+		return SignatureVerificationFailed400
+	case err.Error() == blsagg.TaskNotFoundErrorFn(taskIndex).Error():
+		// the task was initialized (it is in agg.tasks) but has since completed or expired
+		return fmt.Errorf("%w: task %d", TaskClosedError400, taskIndex)
+	default:
+		return fmt.Errorf("%w: %v", UnknownErrorWhileProcessingSignature500, err)
+	}
+}
@@ -0,0 +1,32 @@
+package aggregator
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/incredible-squaring-avs/core"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestValidateUpkeepReceipt(t *testing.T) {
+	tests := []struct {
+		name          string
+		txStatus      uint8
+		receiptStatus uint64
+		valid         bool
+	}{
+		{"succeeded", core.UpkeepTxSucceeded, gethtypes.ReceiptStatusSuccessful, true},
+		{"reverted", core.UpkeepTxReverted, gethtypes.ReceiptStatusFailed, true},
+		{"claims success of a reverted tx", core.UpkeepTxSucceeded, gethtypes.ReceiptStatusFailed, false},
+		{"claims revert of a successful tx", core.UpkeepTxReverted, gethtypes.ReceiptStatusSuccessful, false},
+		{"no status", core.UpkeepTxNone, gethtypes.ReceiptStatusSuccessful, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upkeepResponse := &core.TaskResponse{TxHash: [32]byte{1}, TxStatus: tt.txStatus}
+			err := validateUpkeepReceipt(upkeepResponse, &gethtypes.Receipt{Status: tt.receiptStatus})
+			if (err == nil) != tt.valid {
+				t.Errorf("validateUpkeepReceipt() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
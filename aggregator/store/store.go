@@ -0,0 +1,265 @@
+// Package store persists the aggregator's in-flight tasks, the task responses
+// it was sent and the operator signatures over them, so that an aggregator
+// that restarts can resume aggregating where it stopped.
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	sdktypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/Layr-Labs/incredible-squaring-avs/aggregator/types"
+	"github.com/Layr-Labs/incredible-squaring-avs/core"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Layout: the top level tasks bucket holds one bucket per task, keyed by the
+// big endian task index. Each task bucket holds the TaskRecord under taskKey,
+// a responses bucket keyed by digest and a signatures bucket keyed by operator id.
+// The failed bucket holds a FailedTask per task index for the tasks the aggregator gave up on.
+var (
+	tasksBucket      = []byte("tasks")
+	failedBucket     = []byte("failed")
+	taskKey          = []byte("task")
+	responsesBucket  = []byte("responses")
+	signaturesBucket = []byte("signatures")
+)
+
+const openTimeout = 5 * time.Second
+
+// ErrTaskNotStored is returned when storing a signature for a task that is not
+// stored, either because it was never stored or because it has been deleted.
+var ErrTaskNotStored = errors.New("task is not stored")
+
+// TaskRecord is a task the aggregator initialized, with the time its aggregation expires.
+type TaskRecord struct {
+	Task      types.Task
+	ExpiresAt time.Time
+}
+
+// Signature is an operator's signature over one of a task's response digests.
+type Signature struct {
+	OperatorId         sdktypes.OperatorId
+	TaskResponseDigest sdktypes.TaskResponseDigest
+	BlsSignature       bls.Signature
+}
+
+// Store is a bbolt backed store of aggregator state. It is safe for concurrent use.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the store at path, creating it if it does not exist.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open aggregator store %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(tasksBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(failedBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// PutTask stores a newly initialized task. Responses and signatures stored
+// for an earlier task with the same index are dropped.
+func (s *Store) PutTask(record TaskRecord) error {
+	value, err := encode(record)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		tasks := tx.Bucket(tasksBucket)
+		key := taskIndexKey(record.Task.TaskId)
+		if tasks.Bucket(key) != nil {
+			if err := tasks.DeleteBucket(key); err != nil {
+				return err
+			}
+		}
+		task, err := tasks.CreateBucket(key)
+		if err != nil {
+			return err
+		}
+		if _, err := task.CreateBucket(responsesBucket); err != nil {
+			return err
+		}
+		if _, err := task.CreateBucket(signaturesBucket); err != nil {
+			return err
+		}
+		return task.Put(taskKey, value)
+	})
+}
+
+// DeleteTask removes a task along with its responses and signatures.
+// Deleting a task that is not stored is not an error.
+func (s *Store) DeleteTask(taskIndex types.TaskIndex) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		err := tx.Bucket(tasksBucket).DeleteBucket(taskIndexKey(taskIndex))
+		if errors.Is(err, bolt.ErrBucketNotFound) {
+			return nil
+		}
+		return err
+	})
+}
+
+// PutFailedTask records that the aggregator gave up on a task, and removes
+// the task along with its responses and signatures.
+func (s *Store) PutFailedTask(failedTask types.FailedTask) error {
+	value, err := encode(failedTask)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		key := taskIndexKey(failedTask.Task.TaskId)
+		err := tx.Bucket(tasksBucket).DeleteBucket(key)
+		if err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+			return err
+		}
+		return tx.Bucket(failedBucket).Put(key, value)
+	})
+}
+
+// FailedTasks returns every task recorded by PutFailedTask, by task index.
+func (s *Store) FailedTasks() ([]types.FailedTask, error) {
+	var failedTasks []types.FailedTask
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(failedBucket).ForEach(func(_, value []byte) error {
+			var failedTask types.FailedTask
+			if err := decode(value, &failedTask); err != nil {
+				return err
+			}
+			failedTasks = append(failedTasks, failedTask)
+			return nil
+		})
+	})
+	return failedTasks, err
+}
+
+// PutSignature stores an operator's signature along with the task response it signed.
+func (s *Store) PutSignature(taskIndex types.TaskIndex, taskResponse core.TaskResponse, signature Signature) error {
+	responseValue, err := encode(taskResponse)
+	if err != nil {
+		return err
+	}
+	signatureValue, err := encode(signature)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		task := tx.Bucket(tasksBucket).Bucket(taskIndexKey(taskIndex))
+		if task == nil {
+			return fmt.Errorf("task %d: %w", taskIndex, ErrTaskNotStored)
+		}
+		responses := task.Bucket(responsesBucket)
+		if responses.Get(signature.TaskResponseDigest[:]) == nil {
+			if err := responses.Put(signature.TaskResponseDigest[:], responseValue); err != nil {
+				return err
+			}
+		}
+		return task.Bucket(signaturesBucket).Put(signature.OperatorId[:], signatureValue)
+	})
+}
+
+// GetSignature returns the signature the operator sent for the task, if any.
+func (s *Store) GetSignature(taskIndex types.TaskIndex, operatorId sdktypes.OperatorId) (Signature, bool, error) {
+	var signature Signature
+	var found bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		task := tx.Bucket(tasksBucket).Bucket(taskIndexKey(taskIndex))
+		if task == nil {
+			return nil
+		}
+		value := task.Bucket(signaturesBucket).Get(operatorId[:])
+		if value == nil {
+			return nil
+		}
+		found = true
+		return decode(value, &signature)
+	})
+	return signature, found, err
+}
+
+// StoredTask is everything stored for one task.
+type StoredTask struct {
+	TaskRecord
+	TaskResponses map[sdktypes.TaskResponseDigest]core.TaskResponse
+	Signatures    []Signature
+}
+
+// Tasks returns every stored task.
+func (s *Store) Tasks() ([]StoredTask, error) {
+	var storedTasks []StoredTask
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(tasksBucket).ForEachBucket(func(key []byte) error {
+			task := tx.Bucket(tasksBucket).Bucket(key)
+			storedTask := StoredTask{
+				TaskResponses: make(map[sdktypes.TaskResponseDigest]core.TaskResponse),
+			}
+			if err := decode(task.Get(taskKey), &storedTask.TaskRecord); err != nil {
+				return fmt.Errorf("task %d: %w", binary.BigEndian.Uint32(key), err)
+			}
+			err := task.Bucket(responsesBucket).ForEach(func(digest, value []byte) error {
+				var taskResponse core.TaskResponse
+				if err := decode(value, &taskResponse); err != nil {
+					return err
+				}
+				storedTask.TaskResponses[sdktypes.TaskResponseDigest(digest)] = taskResponse
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("task %d: %w", storedTask.Task.TaskId, err)
+			}
+			err = task.Bucket(signaturesBucket).ForEach(func(_, value []byte) error {
+				var signature Signature
+				if err := decode(value, &signature); err != nil {
+					return err
+				}
+				storedTask.Signatures = append(storedTask.Signatures, signature)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("task %d: %w", storedTask.Task.TaskId, err)
+			}
+			storedTasks = append(storedTasks, storedTask)
+			return nil
+		})
+	})
+	return storedTasks, err
+}
+
+func taskIndexKey(taskIndex types.TaskIndex) []byte {
+	return binary.BigEndian.AppendUint32(nil, taskIndex)
+}
+
+// values are gob encoded, like the SignedTaskResponses keepers send over net/rpc
+func encode(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decode(data []byte, v any) error {
+	if data == nil {
+		return errors.New("missing value")
+	}
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
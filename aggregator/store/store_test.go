@@ -0,0 +1,89 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	sdktypes "github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/Layr-Labs/incredible-squaring-avs/aggregator/types"
+	"github.com/Layr-Labs/incredible-squaring-avs/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreSurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aggregator.db")
+	s, err := Open(path)
+	require.NoError(t, err)
+
+	task := types.Task{
+		TaskId:                    7,
+		JobId:                     3,
+		TaskType:                  "time",
+		TaskCreatedBlock:          100,
+		QuorumNumbers:             sdktypes.QuorumNums{0},
+		QuorumThresholdPercentage: 67,
+	}
+	expiresAt := time.Now().Add(time.Minute).Round(0)
+	require.NoError(t, s.PutTask(TaskRecord{Task: task, ExpiresAt: expiresAt}))
+
+	keyPair, err := bls.NewKeyPairFromString("12248929636257230549931416853095037629726205319386239410403476017439825112537")
+	require.NoError(t, err)
+	taskResponse := core.TaskResponse{Version: core.TaskResponseVersion, ReferenceTaskId: 7, JobId: 3, Status: core.TaskStatusSucceeded}
+	digest := sdktypes.TaskResponseDigest{1, 2, 3}
+	signature := Signature{
+		OperatorId:         sdktypes.OperatorId{9},
+		TaskResponseDigest: digest,
+		BlsSignature:       *keyPair.SignMessage(digest),
+	}
+	require.NoError(t, s.PutSignature(task.TaskId, taskResponse, signature))
+	require.ErrorIs(t, s.PutSignature(8, taskResponse, signature), ErrTaskNotStored)
+	require.NoError(t, s.Close())
+
+	s, err = Open(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	stored, found, err := s.GetSignature(task.TaskId, signature.OperatorId)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, digest, stored.TaskResponseDigest)
+	assert.True(t, stored.BlsSignature.Equal(signature.BlsSignature.G1Affine))
+
+	storedTasks, err := s.Tasks()
+	require.NoError(t, err)
+	require.Len(t, storedTasks, 1)
+	assert.Equal(t, task, storedTasks[0].Task)
+	assert.True(t, expiresAt.Equal(storedTasks[0].ExpiresAt))
+	assert.Equal(t, taskResponse, storedTasks[0].TaskResponses[digest])
+	assert.Len(t, storedTasks[0].Signatures, 1)
+
+	require.NoError(t, s.DeleteTask(task.TaskId))
+	require.NoError(t, s.DeleteTask(task.TaskId))
+	storedTasks, err = s.Tasks()
+	require.NoError(t, err)
+	assert.Empty(t, storedTasks)
+}
+
+func TestPutFailedTaskRemovesTask(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "aggregator.db"))
+	require.NoError(t, err)
+	defer s.Close()
+
+	task := types.Task{TaskId: 1, JobId: 1, QuorumNumbers: sdktypes.QuorumNums{0}, QuorumThresholdPercentage: 100}
+	require.NoError(t, s.PutTask(TaskRecord{Task: task, ExpiresAt: time.Now()}))
+	failedTask := types.FailedTask{Task: task, Reason: types.TaskFailureExpired, Detail: "task 1 expired", FailedAt: time.Now().Round(0)}
+	require.NoError(t, s.PutFailedTask(failedTask))
+
+	storedTasks, err := s.Tasks()
+	require.NoError(t, err)
+	assert.Empty(t, storedTasks)
+	failedTasks, err := s.FailedTasks()
+	require.NoError(t, err)
+	require.Len(t, failedTasks, 1)
+	assert.Equal(t, failedTask.Reason, failedTasks[0].Reason)
+	assert.Equal(t, task, failedTasks[0].Task)
+	assert.True(t, failedTask.FailedAt.Equal(failedTasks[0].FailedAt))
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"time"
+
+	sdktypes "github.com/Layr-Labs/eigensdk-go/types"
+)
+
+type BlockNumber = uint32
+type TaskIndex = sdktypes.TaskIndex
+
+// Task is what the aggregator keeps about a task while it aggregates signatures for it.
+// The quorums and threshold come from the task's job.
+type Task struct {
+	TaskId                    TaskIndex
+	JobId                     uint32
+	TaskType                  string
+	TaskCreatedBlock          BlockNumber
+	QuorumNumbers             sdktypes.QuorumNums
+	QuorumThresholdPercentage sdktypes.QuorumThresholdPercentage
+}
+
+// TaskFailureReason says why the aggregator could not respond to a task.
+type TaskFailureReason string
+
+const (
+	// no keeper sent a response before the task expired
+	TaskFailureExpired TaskFailureReason = "expired"
+	// keepers responded, but no response reached the job's quorum threshold before the task expired
+	TaskFailureQuorumNotMet TaskFailureReason = "quorum_not_met"
+	// the bls aggregation service could not set up or finish aggregating the task
+	TaskFailureAggregation TaskFailureReason = "aggregation_failed"
+	// respondToTask reverted
+	TaskFailureTxReverted TaskFailureReason = "tx_reverted"
+	// respondToTask kept failing for transient reasons until the retries ran out
+	TaskFailureSubmission TaskFailureReason = "submission_failed"
+)
+
+// FailedTask is a task the aggregator gave up on.
+type FailedTask struct {
+	Task     Task
+	Reason   TaskFailureReason
+	Detail   string
+	FailedAt time.Time
+}
@@ -23,9 +23,15 @@ rm -rf bindings/*
 forge clean
 forge build
 
-avs_service_contracts="IncredibleSquaringServiceManager IncredibleSquaringTaskManager"
+avs_service_contracts="KeeperNetworkServiceManager KeeperNetworkTaskManager KeeperNetworkJobManager"
 for contract in $avs_service_contracts; do
     create_binding . $contract ./bindings
 done
 
+# the task manager is its own go module, so it gets its own copy of the keeper bindings
+rm -rf ../taskmanager/bindings/*
+for contract in $avs_service_contracts; do
+    create_binding . $contract ../taskmanager/bindings
+done
+
 create_binding . ERC20Mock ./bindings
@@ -18,6 +18,12 @@ interface IKeeperNetworkTaskManager {
         uint32 indexed taskId,
         address indexed challenger
     );
+    event UpkeepRecorded(
+        uint32 indexed taskId,
+        bytes32 indexed operatorId,
+        bytes32 txHash,
+        uint8 txStatus
+    );
 
     // STRUCTS
     struct Task {
@@ -29,8 +35,15 @@ interface IKeeperNetworkTaskManager {
     }
 
     struct TaskResponse {
+        uint8 version;
         uint32 referenceTaskId;
-        uint256 numberSquared;
+        uint32 jobId;
+        uint8 status;
+        bytes32 resultHash;
+        bytes32 txHash;
+        uint8 txStatus;
+        uint64 checkBlock;
+        bytes32 checkResultHash;
     }
 
     struct TaskResponseMetadata {
@@ -39,6 +52,10 @@ interface IKeeperNetworkTaskManager {
     }
 
     // FUNCTIONS
+    function taskResponseDigest(
+        TaskResponse calldata taskResponse
+    ) external view returns (bytes32);
+
     function createTask(
         uint32 jobId,
         string calldata taskType,
@@ -58,6 +75,12 @@ interface IKeeperNetworkTaskManager {
         BN254.G1Point[] memory pubkeysOfNonSigningOperators
     ) external;
 
+    function recordUpkeep(
+        uint32 taskId,
+        TaskResponse calldata upkeepResponse,
+        bytes32 operatorId
+    ) external;
+
     function raiseAndResolveChallenge(
         Task calldata task,
         TaskResponse calldata taskResponse,
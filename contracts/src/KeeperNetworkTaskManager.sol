@@ -31,6 +31,12 @@ contract KeeperNetworkTaskManager is Initializable,
     //     uint32 indexed taskId,
     //     address indexed challenger
     // );
+    // event UpkeepRecorded(
+    //     uint32 indexed taskId,
+    //     bytes32 indexed operatorId,
+    //     bytes32 txHash,
+    //     uint8 txStatus
+    // );
 
     // STRUCTS - all declared in the interface
     // struct Task {
@@ -42,8 +48,15 @@ contract KeeperNetworkTaskManager is Initializable,
     // }
 
     // struct TaskResponse {
+    //     uint8 version;
     //     uint32 referenceTaskId;
-    //     uint256 numberSquared;
+    //     uint32 jobId;
+    //     uint8 status;
+    //     bytes32 resultHash;
+    //     bytes32 txHash;
+    //     uint8 txStatus;
+    //     uint64 checkBlock;
+    //     bytes32 checkResultHash;
     // }
 
     // struct TaskResponseMetadata {
@@ -55,6 +68,14 @@ contract KeeperNetworkTaskManager is Initializable,
     uint32 public immutable TASK_RESPONSE_WINDOW_BLOCK;
     uint32 public constant TASK_CHALLENGE_WINDOW_BLOCK = 100;
     uint256 internal constant _THRESHOLD_DENOMINATOR = 100;
+    // the TaskResponse layout taskResponseDigest encodes, see core.TaskResponseVersion
+    uint8 internal constant _TASK_RESPONSE_VERSION = 3;
+    // UpkeepRecorded.txStatus, see core.UpkeepTxSucceeded
+    uint8 internal constant _UPKEEP_TX_SUCCEEDED = 1;
+    uint8 internal constant _UPKEEP_TX_REVERTED = 2;
+    // must match taskResponseDomainTypehash in core/task_response.go
+    bytes32 public constant TASK_RESPONSE_DOMAIN_TYPEHASH =
+        keccak256("KeeperNetworkTaskResponse(uint256 chainId,address taskManager)");
 
     IRegistryCoordinator public registryCoordinator;
     address public aggregator;
@@ -118,10 +139,39 @@ contract KeeperNetworkTaskManager is Initializable,
         BN254.G1Point[] memory pubkeysOfNonSigningOperators
     ) external {
         require(tasks[taskId].taskId != 0, "Task does not exist");
+        require(taskResponse.version == _TASK_RESPONSE_VERSION, "Unsupported task response version");
         // Logic to handle task response
         emit TaskCompleted(taskId);
     }
 
+    // records the upkeep transaction the operator the task is assigned to sent,
+    // from its task response signed again with the transaction's hash and status
+    function recordUpkeep(
+        uint32 taskId,
+        TaskResponse calldata upkeepResponse,
+        bytes32 operatorId
+    ) external {
+        require(tasks[taskId].taskId != 0, "Task does not exist");
+        require(upkeepResponse.version == _TASK_RESPONSE_VERSION, "Unsupported task response version");
+        require(upkeepResponse.txHash != bytes32(0), "Upkeep response has no transaction");
+        require(
+            upkeepResponse.txStatus == _UPKEEP_TX_SUCCEEDED || upkeepResponse.txStatus == _UPKEEP_TX_REVERTED,
+            "Unknown upkeep transaction status"
+        );
+        // Logic to verify the operator's signature
+        emit UpkeepRecorded(taskId, operatorId, upkeepResponse.txHash, upkeepResponse.txStatus);
+    }
+
+    // the digest operators sign over, see core.GetTaskResponseDigest
+    function taskResponseDigest(
+        TaskResponse calldata taskResponse
+    ) public view returns (bytes32) {
+        bytes32 domainSeparator = keccak256(
+            abi.encode(TASK_RESPONSE_DOMAIN_TYPEHASH, block.chainid, address(this))
+        );
+        return keccak256(abi.encode(domainSeparator, keccak256(abi.encode(taskResponse))));
+    }
+
     function raiseAndResolveChallenge(
         Task calldata task,
         TaskResponse calldata taskResponse,
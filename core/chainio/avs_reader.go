@@ -2,6 +2,7 @@ package chainio
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -9,18 +10,33 @@ import (
 	sdkavsregistry "github.com/Layr-Labs/eigensdk-go/chainio/clients/avsregistry"
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
 	logging "github.com/Layr-Labs/eigensdk-go/logging"
+	sdktypes "github.com/Layr-Labs/eigensdk-go/types"
 
 	erc20mock "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/ERC20Mock"
-	cstaskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/IncredibleSquaringTaskManager"
+	taskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/KeeperNetworkTaskManager"
 	"github.com/Layr-Labs/incredible-squaring-avs/core/config"
 )
 
+// Job is the onchain state of a job, as stored by KeeperNetworkJobManager.
+type Job struct {
+	JobId          uint32
+	JobType        string
+	JobDescription string
+	JobURL         string
+	Status         string
+	// the quorums whose operators sign the job's task responses, and the percentage
+	// of stake in each quorum that has to sign for a response to be accepted
+	QuorumNumbers             sdktypes.QuorumNums
+	QuorumThresholdPercentage sdktypes.QuorumThresholdPercentage
+	Timeframe                 uint32
+	BlockNumber               uint64
+}
+
 type AvsReaderer interface {
 	sdkavsregistry.AvsRegistryReader
 
-	CheckSignatures(
-		ctx context.Context, msgHash [32]byte, quorumNumbers []byte, referenceBlockNumber uint32, nonSignerStakesAndSignature cstaskmanager.IBLSSignatureCheckerNonSignerStakesAndSignature,
-	) (cstaskmanager.IBLSSignatureCheckerQuorumStakeTotals, error)
+	GetTask(ctx context.Context, taskId uint32) (taskmanager.IKeeperNetworkTaskManagerTask, error)
+	GetJob(ctx context.Context, jobId uint32) (Job, error)
 	GetErc20Mock(ctx context.Context, tokenAddr gethcommon.Address) (*erc20mock.ContractERC20Mock, error)
 }
 
@@ -54,16 +70,49 @@ func NewAvsReader(avsRegistryReader sdkavsregistry.AvsRegistryReader, avsService
 	}, nil
 }
 
-func (r *AvsReader) CheckSignatures(
-	ctx context.Context, msgHash [32]byte, quorumNumbers []byte, referenceBlockNumber uint32, nonSignerStakesAndSignature cstaskmanager.IBLSSignatureCheckerNonSignerStakesAndSignature,
-) (cstaskmanager.IBLSSignatureCheckerQuorumStakeTotals, error) {
-	stakeTotalsPerQuorum, _, err := r.AvsServiceBindings.TaskManager.CheckSignatures(
-		&bind.CallOpts{}, msgHash, quorumNumbers, referenceBlockNumber, nonSignerStakesAndSignature,
-	)
+func (r *AvsReader) GetTask(ctx context.Context, taskId uint32) (taskmanager.IKeeperNetworkTaskManagerTask, error) {
+	task, err := r.AvsServiceBindings.TaskManager.Tasks(&bind.CallOpts{Context: ctx}, taskId)
+	if err != nil {
+		return taskmanager.IKeeperNetworkTaskManagerTask{}, err
+	}
+	if task.TaskId == 0 {
+		return taskmanager.IKeeperNetworkTaskManagerTask{}, fmt.Errorf("task %d does not exist", taskId)
+	}
+	return taskmanager.IKeeperNetworkTaskManagerTask{
+		TaskId:      task.TaskId,
+		JobId:       task.JobId,
+		TaskType:    task.TaskType,
+		Status:      task.Status,
+		BlockNumber: task.BlockNumber,
+	}, nil
+}
+
+func (r *AvsReader) GetJob(ctx context.Context, jobId uint32) (Job, error) {
+	job, err := r.AvsServiceBindings.JobManager.Jobs(&bind.CallOpts{Context: ctx}, jobId)
 	if err != nil {
-		return cstaskmanager.IBLSSignatureCheckerQuorumStakeTotals{}, err
+		return Job{}, err
+	}
+	if job.JobId == nil || job.JobId.Sign() == 0 {
+		return Job{}, fmt.Errorf("job %d does not exist", jobId)
 	}
-	return stakeTotalsPerQuorum, nil
+	if job.QuorumThresholdPercentage > 100 {
+		return Job{}, fmt.Errorf("job %d has invalid quorum threshold percentage %d", jobId, job.QuorumThresholdPercentage)
+	}
+	quorumNumbers := make(sdktypes.QuorumNums, len(job.QuorumNumbers))
+	for i, quorumNumber := range job.QuorumNumbers {
+		quorumNumbers[i] = sdktypes.QuorumNum(quorumNumber)
+	}
+	return Job{
+		JobId:                     jobId,
+		JobType:                   job.JobType,
+		JobDescription:            job.JobDescription,
+		JobURL:                    job.Gitlink,
+		Status:                    job.Status,
+		QuorumNumbers:             quorumNumbers,
+		QuorumThresholdPercentage: sdktypes.QuorumThresholdPercentage(job.QuorumThresholdPercentage),
+		Timeframe:                 job.Timeframe,
+		BlockNumber:               job.BlockNumber.Uint64(),
+	}, nil
 }
 
 func (r *AvsReader) GetErc20Mock(ctx context.Context, tokenAddr gethcommon.Address) (*erc20mock.ContractERC20Mock, error) {
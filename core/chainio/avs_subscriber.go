@@ -1,6 +1,9 @@
 package chainio
 
 import (
+	"context"
+
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -9,72 +12,121 @@ import (
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
 	sdklogging "github.com/Layr-Labs/eigensdk-go/logging"
 
-	cstaskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/IncredibleSquaringTaskManager"
+	jobmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/KeeperNetworkJobManager"
+	taskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/KeeperNetworkTaskManager"
 	"github.com/Layr-Labs/incredible-squaring-avs/core/config"
+	"github.com/Layr-Labs/incredible-squaring-avs/core/logstream"
+	"github.com/Layr-Labs/incredible-squaring-avs/core/reorg"
 )
 
 type AvsSubscriberer interface {
-	SubscribeToNewTasks(newTaskCreatedChan chan *cstaskmanager.ContractIncredibleSquaringTaskManagerNewTaskCreated) event.Subscription
-	SubscribeToTaskResponses(taskResponseLogs chan *cstaskmanager.ContractIncredibleSquaringTaskManagerTaskResponded) event.Subscription
-	ParseTaskResponded(rawLog types.Log) (*cstaskmanager.ContractIncredibleSquaringTaskManagerTaskResponded, error)
+	SubscribeToNewHeads(headers chan *types.Header) event.Subscription
+	SubscribeToNewJobs(newJobCreatedChan chan *jobmanager.ContractKeeperNetworkJobManagerJobCreated) event.Subscription
+	SubscribeToNewTasks(newTaskCreatedChan chan *taskmanager.ContractKeeperNetworkTaskManagerTaskCreated) event.Subscription
+	SubscribeToTaskResponses(taskResponseLogs chan *taskmanager.ContractKeeperNetworkTaskManagerTaskResponded) event.Subscription
+	ParseTaskResponded(rawLog types.Log) (*taskmanager.ContractKeeperNetworkTaskManagerTaskResponded, error)
 }
 
 // Subscribers use a ws connection instead of http connection like Readers
 // kind of stupid that the geth client doesn't have a unified interface for both...
 // it takes a single url, so the bindings, even though they have watcher functions, those can't be used
 // with the http connection... seems very very stupid. Am I missing something?
+//
+// The subscriptions heal themselves: while the websocket is down they poll the http connection for the
+// new logs, and when it is back they fetch the logs sent in between (see logstream.Stream). So they
+// never fail, and the logs they deliver can be Removed ones when there are reorgs.
 type AvsSubscriber struct {
 	AvsContractBindings *AvsManagersBindings
+	ethWsClient         eth.Client
+	ethHttpClient       eth.Client
+	metrics             SubscriptionMetrics
 	logger              sdklogging.Logger
 }
 
-func BuildAvsSubscriberFromConfig(config *config.Config) (*AvsSubscriber, error) {
+func BuildAvsSubscriberFromConfig(config *config.Config, metrics SubscriptionMetrics) (*AvsSubscriber, error) {
 	return BuildAvsSubscriber(
 		config.IncredibleSquaringRegistryCoordinatorAddr,
 		config.OperatorStateRetrieverAddr,
 		config.EthWsClient,
+		config.EthHttpClient,
+		metrics,
 		config.Logger,
 	)
 }
 
-func BuildAvsSubscriber(registryCoordinatorAddr, blsOperatorStateRetrieverAddr gethcommon.Address, ethclient eth.Client, logger sdklogging.Logger) (*AvsSubscriber, error) {
-	avsContractBindings, err := NewAvsManagersBindings(registryCoordinatorAddr, blsOperatorStateRetrieverAddr, ethclient, logger)
+func BuildAvsSubscriber(registryCoordinatorAddr, blsOperatorStateRetrieverAddr gethcommon.Address, ethWsClient, ethHttpClient eth.Client, metrics SubscriptionMetrics, logger sdklogging.Logger) (*AvsSubscriber, error) {
+	avsContractBindings, err := NewAvsManagersBindings(registryCoordinatorAddr, blsOperatorStateRetrieverAddr, ethHttpClient, logger)
 	if err != nil {
 		logger.Errorf("Failed to create contract bindings", "err", err)
 		return nil, err
 	}
-	return NewAvsSubscriber(avsContractBindings, logger), nil
+	return NewAvsSubscriber(avsContractBindings, ethWsClient, ethHttpClient, metrics, logger), nil
 }
 
-func NewAvsSubscriber(avsContractBindings *AvsManagersBindings, logger sdklogging.Logger) *AvsSubscriber {
+// NewAvsSubscriber creates an AvsSubscriber. metrics can be nil.
+func NewAvsSubscriber(avsContractBindings *AvsManagersBindings, ethWsClient, ethHttpClient eth.Client, metrics SubscriptionMetrics, logger sdklogging.Logger) *AvsSubscriber {
+	if metrics == nil {
+		metrics = noopSubscriptionMetrics{}
+	}
 	return &AvsSubscriber{
 		AvsContractBindings: avsContractBindings,
+		ethWsClient:         ethWsClient,
+		ethHttpClient:       ethHttpClient,
+		metrics:             metrics,
 		logger:              logger,
 	}
 }
 
-func (s *AvsSubscriber) SubscribeToNewTasks(newTaskCreatedChan chan *cstaskmanager.ContractIncredibleSquaringTaskManagerNewTaskCreated) event.Subscription {
-	sub, err := s.AvsContractBindings.TaskManager.WatchNewTaskCreated(
-		&bind.WatchOpts{}, newTaskCreatedChan, nil,
-	)
-	if err != nil {
-		s.logger.Error("Failed to subscribe to new TaskManager tasks", "err", err)
-	}
-	s.logger.Infof("Subscribed to new TaskManager tasks")
-	return sub
+// SubscribeToNewHeads lets subscribers of the events below tell how deep their logs are, and
+// notice the reorgs that remove them (see core/reorg).
+func (s *AvsSubscriber) SubscribeToNewHeads(headers chan *types.Header) event.Subscription {
+	s.logger.Infof("Subscribing to new heads")
+	return s.subscribe("NewHeads", &logstream.Stream{OnHead: sendTo(headers)})
 }
 
-func (s *AvsSubscriber) SubscribeToTaskResponses(taskResponseChan chan *cstaskmanager.ContractIncredibleSquaringTaskManagerTaskResponded) event.Subscription {
-	sub, err := s.AvsContractBindings.TaskManager.WatchTaskResponded(
-		&bind.WatchOpts{}, taskResponseChan,
-	)
-	if err != nil {
-		s.logger.Error("Failed to subscribe to TaskResponded events", "err", err)
-	}
-	s.logger.Infof("Subscribed to TaskResponded events")
-	return sub
+func (s *AvsSubscriber) SubscribeToNewJobs(newJobCreatedChan chan *jobmanager.ContractKeeperNetworkJobManagerJobCreated) event.Subscription {
+	s.logger.Infof("Subscribing to new JobManager jobs")
+	return subscribeToEvent(s, s.AvsContractBindings.JobManagerAddr, jobmanager.ContractKeeperNetworkJobManagerMetaData, "JobCreated",
+		s.AvsContractBindings.JobManager.ParseJobCreated, newJobCreatedChan)
 }
 
-func (s *AvsSubscriber) ParseTaskResponded(rawLog types.Log) (*cstaskmanager.ContractIncredibleSquaringTaskManagerTaskResponded, error) {
-	return s.AvsContractBindings.TaskManager.ContractIncredibleSquaringTaskManagerFilterer.ParseTaskResponded(rawLog)
+func (s *AvsSubscriber) SubscribeToNewTasks(newTaskCreatedChan chan *taskmanager.ContractKeeperNetworkTaskManagerTaskCreated) event.Subscription {
+	s.logger.Infof("Subscribing to new TaskManager tasks")
+	return subscribeToEvent(s, s.AvsContractBindings.TaskManagerAddr, taskmanager.ContractKeeperNetworkTaskManagerMetaData, "TaskCreated",
+		s.AvsContractBindings.TaskManager.ParseTaskCreated, newTaskCreatedChan)
+}
+
+func (s *AvsSubscriber) SubscribeToTaskResponses(taskResponseChan chan *taskmanager.ContractKeeperNetworkTaskManagerTaskResponded) event.Subscription {
+	s.logger.Infof("Subscribing to TaskResponded events")
+	return subscribeToEvent(s, s.AvsContractBindings.TaskManagerAddr, taskmanager.ContractKeeperNetworkTaskManagerMetaData, "TaskResponded",
+		s.AvsContractBindings.TaskManager.ParseTaskResponded, taskResponseChan)
+}
+
+func (s *AvsSubscriber) ParseTaskResponded(rawLog types.Log) (*taskmanager.ContractKeeperNetworkTaskManagerTaskResponded, error) {
+	return s.AvsContractBindings.TaskManager.ContractKeeperNetworkTaskManagerFilterer.ParseTaskResponded(rawLog)
+}
+
+// subscribeToEvent delivers the eventName events of the contract at addr to ch, parsed with parse.
+func subscribeToEvent[T any](s *AvsSubscriber, addr gethcommon.Address, metaData *bind.MetaData, eventName string, parse func(types.Log) (*T, error), ch chan *T) event.Subscription {
+	contractAbi, err := metaData.GetAbi()
+	if err != nil {
+		// the abi is generated, so this cannot happen
+		panic(err)
+	}
+	send := sendTo(ch)
+	return s.subscribe(eventName, &logstream.Stream{
+		Query: ethereum.FilterQuery{
+			Addresses: []gethcommon.Address{addr},
+			Topics:    [][]gethcommon.Hash{{contractAbi.Events[eventName].ID}},
+		},
+		Heads: reorg.NewDetector(s.ethHttpClient, reorgWindow),
+		OnLog: func(ctx context.Context, vLog types.Log) {
+			event, err := parse(vLog)
+			if err != nil {
+				s.logger.Error("Failed to parse log", "event", eventName, "txHash", vLog.TxHash, "err", err)
+				return
+			}
+			send(ctx, event)
+		},
+	})
 }
@@ -2,7 +2,7 @@ package chainio
 
 import (
 	"context"
-	"math/big"
+	"fmt"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -11,33 +11,47 @@ import (
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/eth"
 	"github.com/Layr-Labs/eigensdk-go/chainio/txmgr"
 	logging "github.com/Layr-Labs/eigensdk-go/logging"
-	sdktypes "github.com/Layr-Labs/eigensdk-go/types"
 
-	cstaskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/IncredibleSquaringTaskManager"
+	taskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/KeeperNetworkTaskManager"
 	"github.com/Layr-Labs/incredible-squaring-avs/core/config"
 )
 
+// The onchain statuses of a Keeper task, from its creation until it is responded to or failed.
+const (
+	TaskStatusCreated   = "created"
+	TaskStatusAssigned  = "assigned"
+	TaskStatusResponded = "responded"
+	TaskStatusFailed    = "failed"
+)
+
 type AvsWriterer interface {
 	avsregistry.AvsRegistryWriter
 
-	SendNewTaskNumberToSquare(
-		ctx context.Context,
-		numToSquare *big.Int,
-		quorumThresholdPercentage sdktypes.QuorumThresholdPercentage,
-		quorumNumbers sdktypes.QuorumNums,
-	) (cstaskmanager.IIncredibleSquaringTaskManagerTask, uint32, error)
+	// CreateTask creates a task of the job with status TaskStatusCreated, and returns its id.
+	CreateTask(ctx context.Context, jobId uint32, taskType string) (uint32, *types.Receipt, error)
+	AssignTask(ctx context.Context, taskId uint32, operator gethcommon.Address) (*types.Receipt, error)
+
 	RaiseChallenge(
 		ctx context.Context,
-		task cstaskmanager.IIncredibleSquaringTaskManagerTask,
-		taskResponse cstaskmanager.IIncredibleSquaringTaskManagerTaskResponse,
-		taskResponseMetadata cstaskmanager.IIncredibleSquaringTaskManagerTaskResponseMetadata,
-		pubkeysOfNonSigningOperators []cstaskmanager.BN254G1Point,
+		task taskmanager.IKeeperNetworkTaskManagerTask,
+		taskResponse taskmanager.IKeeperNetworkTaskManagerTaskResponse,
+		taskResponseMetadata taskmanager.IKeeperNetworkTaskManagerTaskResponseMetadata,
+		pubkeysOfNonSigningOperators []taskmanager.BN254G1Point,
 	) (*types.Receipt, error)
 	SendAggregatedResponse(ctx context.Context,
-		task cstaskmanager.IIncredibleSquaringTaskManagerTask,
-		taskResponse cstaskmanager.IIncredibleSquaringTaskManagerTaskResponse,
-		nonSignerStakesAndSignature cstaskmanager.IBLSSignatureCheckerNonSignerStakesAndSignature,
+		taskId uint32,
+		taskResponse taskmanager.IKeeperNetworkTaskManagerTaskResponse,
+		taskResponseMetadata taskmanager.IKeeperNetworkTaskManagerTaskResponseMetadata,
+		pubkeysOfNonSigningOperators []taskmanager.BN254G1Point,
 	) (*types.Receipt, error)
+	// RecordUpkeep records the upkeep transaction of a task, from the response
+	// the operator who sent it signed for it, see core.TaskResponse.TxHash.
+	RecordUpkeep(ctx context.Context,
+		taskId uint32,
+		upkeepResponse taskmanager.IKeeperNetworkTaskManagerTaskResponse,
+		operatorId [32]byte,
+	) (*types.Receipt, error)
+	UpdateTaskStatus(ctx context.Context, taskId uint32, status string) (*types.Receipt, error)
 }
 
 type AvsWriter struct {
@@ -75,42 +89,62 @@ func NewAvsWriter(avsRegistryWriter avsregistry.AvsRegistryWriter, avsServiceBin
 	}
 }
 
-// returns the tx receipt, as well as the task index (which it gets from parsing the tx receipt logs)
-func (w *AvsWriter) SendNewTaskNumberToSquare(ctx context.Context, numToSquare *big.Int, quorumThresholdPercentage sdktypes.QuorumThresholdPercentage, quorumNumbers sdktypes.QuorumNums) (cstaskmanager.IIncredibleSquaringTaskManagerTask, uint32, error) {
+func (w *AvsWriter) CreateTask(ctx context.Context, jobId uint32, taskType string) (uint32, *types.Receipt, error) {
 	txOpts, err := w.TxMgr.GetNoSendTxOpts()
 	if err != nil {
 		w.logger.Errorf("Error getting tx opts")
-		return cstaskmanager.IIncredibleSquaringTaskManagerTask{}, 0, err
+		return 0, nil, err
 	}
-	tx, err := w.AvsContractBindings.TaskManager.CreateNewTask(txOpts, numToSquare, uint32(quorumThresholdPercentage), quorumNumbers.UnderlyingType())
+	tx, err := w.AvsContractBindings.TaskManager.CreateTask(txOpts, jobId, taskType, TaskStatusCreated)
 	if err != nil {
-		w.logger.Errorf("Error assembling CreateNewTask tx")
-		return cstaskmanager.IIncredibleSquaringTaskManagerTask{}, 0, err
+		w.logger.Error("Error assembling CreateTask tx", "err", err)
+		return 0, nil, err
 	}
 	receipt, err := w.TxMgr.Send(ctx, tx)
 	if err != nil {
-		w.logger.Errorf("Error submitting CreateNewTask tx")
-		return cstaskmanager.IIncredibleSquaringTaskManagerTask{}, 0, err
+		w.logger.Errorf("Error submitting CreateTask tx")
+		return 0, nil, err
+	}
+	for _, vLog := range receipt.Logs {
+		taskCreated, err := w.AvsContractBindings.TaskManager.ParseTaskCreated(*vLog)
+		if err == nil {
+			return taskCreated.TaskId, receipt, nil
+		}
 	}
-	newTaskCreatedEvent, err := w.AvsContractBindings.TaskManager.ContractIncredibleSquaringTaskManagerFilterer.ParseNewTaskCreated(*receipt.Logs[0])
+	return 0, receipt, fmt.Errorf("CreateTask tx %s has no TaskCreated event", receipt.TxHash.Hex())
+}
+
+func (w *AvsWriter) AssignTask(ctx context.Context, taskId uint32, operator gethcommon.Address) (*types.Receipt, error) {
+	txOpts, err := w.TxMgr.GetNoSendTxOpts()
 	if err != nil {
-		w.logger.Error("Aggregator failed to parse new task created event", "err", err)
-		return cstaskmanager.IIncredibleSquaringTaskManagerTask{}, 0, err
+		w.logger.Errorf("Error getting tx opts")
+		return nil, err
 	}
-	return newTaskCreatedEvent.Task, newTaskCreatedEvent.TaskIndex, nil
+	tx, err := w.AvsContractBindings.TaskManager.AssignTask(txOpts, taskId, operator)
+	if err != nil {
+		w.logger.Error("Error assembling AssignTask tx", "err", err)
+		return nil, err
+	}
+	receipt, err := w.TxMgr.Send(ctx, tx)
+	if err != nil {
+		w.logger.Errorf("Error submitting AssignTask tx")
+		return nil, err
+	}
+	return receipt, nil
 }
 
 func (w *AvsWriter) SendAggregatedResponse(
-	ctx context.Context, task cstaskmanager.IIncredibleSquaringTaskManagerTask,
-	taskResponse cstaskmanager.IIncredibleSquaringTaskManagerTaskResponse,
-	nonSignerStakesAndSignature cstaskmanager.IBLSSignatureCheckerNonSignerStakesAndSignature,
+	ctx context.Context, taskId uint32,
+	taskResponse taskmanager.IKeeperNetworkTaskManagerTaskResponse,
+	taskResponseMetadata taskmanager.IKeeperNetworkTaskManagerTaskResponseMetadata,
+	pubkeysOfNonSigningOperators []taskmanager.BN254G1Point,
 ) (*types.Receipt, error) {
 	txOpts, err := w.TxMgr.GetNoSendTxOpts()
 	if err != nil {
 		w.logger.Errorf("Error getting tx opts")
 		return nil, err
 	}
-	tx, err := w.AvsContractBindings.TaskManager.RespondToTask(txOpts, task, taskResponse, nonSignerStakesAndSignature)
+	tx, err := w.AvsContractBindings.TaskManager.RespondToTask(txOpts, taskId, taskResponse, taskResponseMetadata, pubkeysOfNonSigningOperators)
 	if err != nil {
 		w.logger.Error("Error submitting SubmitTaskResponse tx while calling respondToTask", "err", err)
 		return nil, err
@@ -123,12 +157,54 @@ func (w *AvsWriter) SendAggregatedResponse(
 	return receipt, nil
 }
 
+func (w *AvsWriter) RecordUpkeep(
+	ctx context.Context, taskId uint32,
+	upkeepResponse taskmanager.IKeeperNetworkTaskManagerTaskResponse,
+	operatorId [32]byte,
+) (*types.Receipt, error) {
+	txOpts, err := w.TxMgr.GetNoSendTxOpts()
+	if err != nil {
+		w.logger.Errorf("Error getting tx opts")
+		return nil, err
+	}
+	tx, err := w.AvsContractBindings.TaskManager.RecordUpkeep(txOpts, taskId, upkeepResponse, operatorId)
+	if err != nil {
+		w.logger.Error("Error assembling RecordUpkeep tx", "err", err)
+		return nil, err
+	}
+	receipt, err := w.TxMgr.Send(ctx, tx)
+	if err != nil {
+		w.logger.Errorf("Error submitting recordUpkeep tx")
+		return nil, err
+	}
+	return receipt, nil
+}
+
+func (w *AvsWriter) UpdateTaskStatus(ctx context.Context, taskId uint32, status string) (*types.Receipt, error) {
+	txOpts, err := w.TxMgr.GetNoSendTxOpts()
+	if err != nil {
+		w.logger.Errorf("Error getting tx opts")
+		return nil, err
+	}
+	tx, err := w.AvsContractBindings.TaskManager.UpdateTaskStatus(txOpts, taskId, status)
+	if err != nil {
+		w.logger.Error("Error assembling UpdateTaskStatus tx", "err", err)
+		return nil, err
+	}
+	receipt, err := w.TxMgr.Send(ctx, tx)
+	if err != nil {
+		w.logger.Errorf("Error submitting UpdateTaskStatus tx")
+		return nil, err
+	}
+	return receipt, nil
+}
+
 func (w *AvsWriter) RaiseChallenge(
 	ctx context.Context,
-	task cstaskmanager.IIncredibleSquaringTaskManagerTask,
-	taskResponse cstaskmanager.IIncredibleSquaringTaskManagerTaskResponse,
-	taskResponseMetadata cstaskmanager.IIncredibleSquaringTaskManagerTaskResponseMetadata,
-	pubkeysOfNonSigningOperators []cstaskmanager.BN254G1Point,
+	task taskmanager.IKeeperNetworkTaskManagerTask,
+	taskResponse taskmanager.IKeeperNetworkTaskManagerTaskResponse,
+	taskResponseMetadata taskmanager.IKeeperNetworkTaskManagerTaskResponseMetadata,
+	pubkeysOfNonSigningOperators []taskmanager.BN254G1Point,
 ) (*types.Receipt, error) {
 	txOpts, err := w.TxMgr.GetNoSendTxOpts()
 	if err != nil {
@@ -10,15 +10,19 @@ import (
 
 	regcoord "github.com/Layr-Labs/eigensdk-go/contracts/bindings/RegistryCoordinator"
 	erc20mock "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/ERC20Mock"
-	csservicemanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/IncredibleSquaringServiceManager"
-	cstaskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/IncredibleSquaringTaskManager"
+	jobmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/KeeperNetworkJobManager"
+	servicemanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/KeeperNetworkServiceManager"
+	taskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/KeeperNetworkTaskManager"
 )
 
 type AvsManagersBindings struct {
-	TaskManager    *cstaskmanager.ContractIncredibleSquaringTaskManager
-	ServiceManager *csservicemanager.ContractIncredibleSquaringServiceManager
-	ethClient      eth.Client
-	logger         logging.Logger
+	TaskManager     *taskmanager.ContractKeeperNetworkTaskManager
+	JobManager      *jobmanager.ContractKeeperNetworkJobManager
+	ServiceManager  *servicemanager.ContractKeeperNetworkServiceManager
+	TaskManagerAddr gethcommon.Address
+	JobManagerAddr  gethcommon.Address
+	ethClient       eth.Client
+	logger          logging.Logger
 }
 
 func NewAvsManagersBindings(registryCoordinatorAddr, operatorStateRetrieverAddr gethcommon.Address, ethclient eth.Client, logger logging.Logger) (*AvsManagersBindings, error) {
@@ -30,28 +34,42 @@ func NewAvsManagersBindings(registryCoordinatorAddr, operatorStateRetrieverAddr
 	if err != nil {
 		return nil, err
 	}
-	contractServiceManager, err := csservicemanager.NewContractIncredibleSquaringServiceManager(serviceManagerAddr, ethclient)
+	contractServiceManager, err := servicemanager.NewContractKeeperNetworkServiceManager(serviceManagerAddr, ethclient)
 	if err != nil {
 		logger.Error("Failed to fetch IServiceManager contract", "err", err)
 		return nil, err
 	}
 
-	taskManagerAddr, err := contractServiceManager.IncredibleSquaringTaskManager(&bind.CallOpts{})
+	taskManagerAddr, err := contractServiceManager.KeeperNetworkTaskManager(&bind.CallOpts{})
 	if err != nil {
 		logger.Error("Failed to fetch TaskManager address", "err", err)
 		return nil, err
 	}
-	contractTaskManager, err := cstaskmanager.NewContractIncredibleSquaringTaskManager(taskManagerAddr, ethclient)
+	contractTaskManager, err := taskmanager.NewContractKeeperNetworkTaskManager(taskManagerAddr, ethclient)
 	if err != nil {
-		logger.Error("Failed to fetch IIncredibleSquaringTaskManager contract", "err", err)
+		logger.Error("Failed to fetch IKeeperNetworkTaskManager contract", "err", err)
+		return nil, err
+	}
+
+	jobManagerAddr, err := contractServiceManager.KeeperNetworkJobManager(&bind.CallOpts{})
+	if err != nil {
+		logger.Error("Failed to fetch JobManager address", "err", err)
+		return nil, err
+	}
+	contractJobManager, err := jobmanager.NewContractKeeperNetworkJobManager(jobManagerAddr, ethclient)
+	if err != nil {
+		logger.Error("Failed to fetch IKeeperNetworkJobManager contract", "err", err)
 		return nil, err
 	}
 
 	return &AvsManagersBindings{
-		ServiceManager: contractServiceManager,
-		TaskManager:    contractTaskManager,
-		ethClient:      ethclient,
-		logger:         logger,
+		ServiceManager:  contractServiceManager,
+		TaskManager:     contractTaskManager,
+		JobManager:      contractJobManager,
+		TaskManagerAddr: taskManagerAddr,
+		JobManagerAddr:  jobManagerAddr,
+		ethClient:       ethclient,
+		logger:          logger,
 	}, nil
 }
 
@@ -0,0 +1,82 @@
+package chainio
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/incredible-squaring-avs/core/logstream"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// transports the AvsSubscriber follows the chain with
+const (
+	TransportWebsocket = logstream.TransportWebsocket
+	TransportPolling   = logstream.TransportPolling
+)
+
+// SubscriptionMetrics shows the health of the AvsSubscriber's subscriptions, which are named after
+// the event they deliver, or "NewHeads".
+type SubscriptionMetrics interface {
+	// SubscriptionTransport is called with TransportWebsocket or TransportPolling when the subscription switches to it.
+	SubscriptionTransport(subscription string, transport string)
+	// SubscriptionFailed is called when subscribing over websocket failed or a subscription broke.
+	SubscriptionFailed(subscription string)
+	// SubscriptionReconnected is called when a subscription that failed is back over websocket.
+	SubscriptionReconnected(subscription string)
+	// LogsFetched counts the logs fetched with eth_getLogs, while polling or filling the gap after a reconnect.
+	LogsFetched(subscription string, count int)
+}
+
+type noopSubscriptionMetrics struct{}
+
+func (noopSubscriptionMetrics) SubscriptionTransport(string, string) {}
+func (noopSubscriptionMetrics) SubscriptionFailed(string)            {}
+func (noopSubscriptionMetrics) SubscriptionReconnected(string)       {}
+func (noopSubscriptionMetrics) LogsFetched(string, int)              {}
+
+// reorgWindow is the number of blocks the polled heads of a log subscription are tracked for, to
+// notice the reorgs that replace them.
+const reorgWindow = 256
+
+// subscribe runs the stream until the subscription is unsubscribed. The stream heals itself (see
+// logstream.Stream), so the returned subscription never fails. It delivers the events emitted
+// from now on.
+func (s *AvsSubscriber) subscribe(name string, stream *logstream.Stream) event.Subscription {
+	stream.WSClient = s.ethWsClient
+	stream.HTTPClient = s.ethHttpClient
+	stream.Metrics = namedMetrics{name: name, metrics: s.metrics}
+	stream.Logger = s.logger.With("subscription", name)
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-quit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		stream.Run(ctx, logstream.Latest)
+		return nil
+	})
+}
+
+// namedMetrics reports the metrics of one subscription.
+type namedMetrics struct {
+	name    string
+	metrics SubscriptionMetrics
+}
+
+func (m namedMetrics) Transport(transport string) { m.metrics.SubscriptionTransport(m.name, transport) }
+func (m namedMetrics) SubscriptionFailed()        { m.metrics.SubscriptionFailed(m.name) }
+func (m namedMetrics) SubscriptionReconnected()   { m.metrics.SubscriptionReconnected(m.name) }
+func (m namedMetrics) LogsFetched(count int)      { m.metrics.LogsFetched(m.name, count) }
+
+// sendTo returns a deliver function that sends to ch, giving up when ctx is done.
+func sendTo[T any](ch chan T) func(ctx context.Context, value T) {
+	return func(ctx context.Context, value T) {
+		select {
+		case ch <- value:
+		case <-ctx.Done():
+		}
+	}
+}
@@ -27,6 +27,7 @@ type Config struct {
 	BlsPrivateKey             *bls.PrivateKey
 	Logger                    sdklogging.Logger
 	EigenMetricsIpPortAddress string
+	EnableMetrics             bool
 	// we need the url for the eigensdk currently... eventually standardize api so as to
 	// only take an ethclient or an rpcUrl (and build the ethclient at each constructor site)
 	EthHttpRpcUrl                             string
@@ -35,8 +36,16 @@ type Config struct {
 	EthWsClient                               eth.Client
 	OperatorStateRetrieverAddr                common.Address
 	IncredibleSquaringRegistryCoordinatorAddr common.Address
-	AggregatorServerIpPortAddr                string
-	RegisterOperatorOnStartup                 bool
+	// task responses are signed for this task manager, see core.TaskResponseDomain
+	KeeperNetworkTaskManagerAddr common.Address
+	AggregatorServerIpPortAddr   string
+	AggregatorDbPath             string
+	RegisterOperatorOnStartup    bool
+	// whether the aggregator marks the tasks it gives up on as failed onchain, through updateTaskStatus
+	UpdateTaskStatusOnFailure bool
+	// number of blocks that must be built on top of a JobCreated or TaskCreated event's block
+	// before it is acted on, read from ConfirmationDepths for the chain EthRpcUrl is on
+	ConfirmationDepth uint64
 	// json:"-" skips this field when marshaling (only used for logging to stdout), since SignerFn doesnt implement marshalJson
 	SignerFn          signerv2.SignerFn `json:"-"`
 	TxMgr             txmgr.TxManager
@@ -49,7 +58,13 @@ type ConfigRaw struct {
 	EthRpcUrl                  string              `yaml:"eth_rpc_url"`
 	EthWsUrl                   string              `yaml:"eth_ws_url"`
 	AggregatorServerIpPortAddr string              `yaml:"aggregator_server_ip_port_address"`
+	AggregatorDbPath           string              `yaml:"aggregator_db_path"`
 	RegisterOperatorOnStartup  bool                `yaml:"register_operator_on_startup"`
+	EigenMetricsIpPortAddress  string              `yaml:"eigen_metrics_ip_port_address"`
+	EnableMetrics              bool                `yaml:"enable_metrics"`
+	UpdateTaskStatusOnFailure  bool                `yaml:"update_task_status_on_failure"`
+	// confirmation depth by chain id, chains that are not listed use 0
+	ConfirmationDepths map[uint64]uint64 `yaml:"confirmation_depths"`
 }
 
 // These are read from CredibleSquaringDeploymentFileFlag
@@ -59,6 +74,7 @@ type IncredibleSquaringDeploymentRaw struct {
 type IncredibleSquaringContractsRaw struct {
 	RegistryCoordinatorAddr    string `json:"registryCoordinator"`
 	OperatorStateRetrieverAddr string `json:"operatorStateRetriever"`
+	TaskManagerAddr            string `json:"keeperNetworkTaskManager"`
 }
 
 // NewConfig parses config file to read from from flags or environment variables
@@ -131,14 +147,20 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 	config := &Config{
 		EcdsaPrivateKey:            ecdsaPrivateKey,
 		Logger:                     logger,
+		EigenMetricsIpPortAddress:  configRaw.EigenMetricsIpPortAddress,
+		EnableMetrics:              configRaw.EnableMetrics,
 		EthWsRpcUrl:                configRaw.EthWsUrl,
 		EthHttpRpcUrl:              configRaw.EthRpcUrl,
 		EthHttpClient:              ethRpcClient,
 		EthWsClient:                ethWsClient,
 		OperatorStateRetrieverAddr: common.HexToAddress(credibleSquaringDeploymentRaw.Addresses.OperatorStateRetrieverAddr),
 		IncredibleSquaringRegistryCoordinatorAddr: common.HexToAddress(credibleSquaringDeploymentRaw.Addresses.RegistryCoordinatorAddr),
+		KeeperNetworkTaskManagerAddr:              common.HexToAddress(credibleSquaringDeploymentRaw.Addresses.TaskManagerAddr),
 		AggregatorServerIpPortAddr:                configRaw.AggregatorServerIpPortAddr,
+		AggregatorDbPath:                          configRaw.AggregatorDbPath,
 		RegisterOperatorOnStartup:                 configRaw.RegisterOperatorOnStartup,
+		UpdateTaskStatusOnFailure:                 configRaw.UpdateTaskStatusOnFailure,
+		ConfirmationDepth:                         configRaw.ConfirmationDepths[chainId.Uint64()],
 		SignerFn:                                  signerV2,
 		TxMgr:                                     txMgr,
 		AggregatorAddress:                         aggregatorAddr,
@@ -155,6 +177,9 @@ func (c *Config) validate() {
 	if c.IncredibleSquaringRegistryCoordinatorAddr == common.HexToAddress("") {
 		panic("Config: IncredibleSquaringRegistryCoordinatorAddr is required")
 	}
+	if c.KeeperNetworkTaskManagerAddr == common.HexToAddress("") {
+		panic("Config: KeeperNetworkTaskManagerAddr is required")
+	}
 }
 
 var (
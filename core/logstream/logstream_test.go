@@ -0,0 +1,192 @@
+package logstream
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Layr-Labs/incredible-squaring-avs/core/reorg"
+)
+
+// fakeChain serves a chain of linked headers and the logs of its blocks, over
+// http only, and records the ranges of logs asked for.
+type fakeChain struct {
+	headers []*types.Header
+	logs    []types.Log
+	ranges  [][2]uint64
+}
+
+func newFakeChain(head uint64) *fakeChain {
+	c := &fakeChain{headers: []*types.Header{{Number: big.NewInt(0)}}}
+	c.extend(0, head, 0)
+	return c
+}
+
+// extend replaces the blocks after parent with new ones up to head. The seed
+// makes the new blocks differ from the ones they replace.
+func (c *fakeChain) extend(parent uint64, head uint64, seed byte) {
+	c.headers = c.headers[:parent+1]
+	for n := parent + 1; n <= head; n++ {
+		c.headers = append(c.headers, &types.Header{
+			Number:     new(big.Int).SetUint64(n),
+			ParentHash: c.headers[n-1].Hash(),
+			Extra:      []byte{seed},
+		})
+	}
+	var logs []types.Log
+	for _, vLog := range c.logs {
+		if vLog.BlockNumber <= parent {
+			logs = append(logs, vLog)
+		}
+	}
+	c.logs = logs
+}
+
+func (c *fakeChain) addLog(blockNumber uint64, index uint) types.Log {
+	vLog := types.Log{BlockNumber: blockNumber, BlockHash: c.headers[blockNumber].Hash(), Index: index}
+	c.logs = append(c.logs, vLog)
+	return vLog
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *fakeChain) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+	c.ranges = append(c.ranges, [2]uint64{from, to})
+	var logs []types.Log
+	for _, vLog := range c.logs {
+		if vLog.BlockNumber >= from && vLog.BlockNumber <= to {
+			logs = append(logs, vLog)
+		}
+	}
+	return logs, nil
+}
+
+func (c *fakeChain) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("websocket unavailable")
+}
+
+func (c *fakeChain) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return nil, errors.New("websocket unavailable")
+}
+
+func TestCatchUpPaginatesAndDropsDuplicates(t *testing.T) {
+	chain := newFakeChain(12)
+	chain.addLog(3, 0)
+	chain.addLog(12, 1)
+	var delivered []types.Log
+	stream := &Stream{
+		HTTPClient: chain,
+		PageSize:   10,
+		OnLog:      func(ctx context.Context, vLog types.Log) { delivered = append(delivered, vLog) },
+	}
+	stream.init(0)
+	if err := stream.catchUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want := [][2]uint64{{0, 9}, {10, 12}}; len(chain.ranges) != 2 || chain.ranges[0] != want[0] || chain.ranges[1] != want[1] {
+		t.Errorf("expected ranges %v, got %v", want, chain.ranges)
+	}
+
+	// a websocket log the catch up fetches again after a reconnect is delivered once
+	chain.extend(12, 14, 0)
+	stream.deliver(context.Background(), chain.addLog(13, 0))
+	stream.next = 12
+	chain.addLog(14, 2)
+	if err := stream.catchUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]uint64{{3, 0}, {12, 1}, {13, 0}, {14, 2}}
+	if len(delivered) != len(want) {
+		t.Fatalf("expected logs %v, got %v", want, delivered)
+	}
+	for i := range want {
+		if delivered[i].BlockNumber != want[i][0] || uint64(delivered[i].Index) != want[i][1] {
+			t.Fatalf("expected logs %v, got %v", want, delivered)
+		}
+	}
+}
+
+func TestPollingFollowsReorgsWithHeads(t *testing.T) {
+	chain := newFakeChain(12)
+	var delivered []types.Log
+	stream := &Stream{
+		HTTPClient: chain,
+		Heads:      reorg.NewDetector(chain, dedupeWindow),
+		OnLog:      func(ctx context.Context, vLog types.Log) { delivered = append(delivered, vLog) },
+	}
+	stream.init(Latest)
+	catchUp := func() {
+		t.Helper()
+		if err := stream.catchUp(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	catchUp()
+	chain.extend(12, 14, 0)
+	chain.addLog(13, 0)
+	catchUp()
+	// the blocks after 12 are replaced, and the new block 13 has another log
+	chain.extend(12, 15, 1)
+	canonical := chain.addLog(13, 0)
+	catchUp()
+
+	if len(delivered) != 2 || delivered[1].BlockHash != canonical.BlockHash {
+		t.Errorf("expected the log of the old and of the new block 13, got %v", delivered)
+	}
+}
+
+func TestPollingFollowsReorgsWithAConfirmer(t *testing.T) {
+	chain := newFakeChain(12)
+	orphaned := chain.addLog(11, 0)
+
+	confirmer := reorg.NewConfirmer[struct{}](2, chain)
+	var confirmed []types.Log
+	stream := &Stream{HTTPClient: chain, PageSize: 10}
+	handle := func(update reorg.Update[struct{}]) {
+		if update.Reorged {
+			stream.Rewind(update.ForkPoint + 1)
+		}
+		for _, event := range update.Confirmed {
+			confirmed = append(confirmed, event.Log)
+		}
+	}
+	stream.OnHead = func(ctx context.Context, head *types.Header) {
+		update, err := confirmer.AddHead(ctx, head)
+		if err != nil {
+			t.Fatal(err)
+		}
+		handle(update)
+	}
+	stream.OnLog = func(ctx context.Context, vLog types.Log) { handle(confirmer.AddLog(vLog, struct{}{})) }
+	stream.init(0)
+
+	if err := stream.catchUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// the blocks after 10 are replaced, and the new block 11 has another log
+	chain.extend(10, 13, 1)
+	canonical := chain.addLog(11, 0)
+	if err := stream.catchUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	chain.extend(13, 15, 1)
+	if err := stream.catchUp(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(confirmed) != 1 || confirmed[0].BlockHash != canonical.BlockHash {
+		t.Errorf("expected only the log of the new chain %s to be confirmed, got %v (orphaned %s)",
+			canonical.BlockHash, confirmed, orphaned.BlockHash)
+	}
+}
@@ -0,0 +1,171 @@
+package reorg
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeChain serves the headers of its current canonical chain.
+type fakeChain struct {
+	headers []*types.Header
+}
+
+// extend replaces the chain above block from with n new blocks, labelled with fork.
+func (c *fakeChain) extend(from uint64, n int, fork string) {
+	c.headers = c.headers[:from+1]
+	for i := 0; i < n; i++ {
+		parent := c.headers[len(c.headers)-1]
+		c.headers = append(c.headers, &types.Header{
+			Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+			ParentHash: parent.Hash(),
+			Extra:      []byte(fork),
+		})
+	}
+}
+
+func (c *fakeChain) head() *types.Header {
+	return c.headers[len(c.headers)-1]
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number.Uint64() >= uint64(len(c.headers)) {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *fakeChain) logAt(number uint64, index uint) types.Log {
+	return types.Log{BlockNumber: number, BlockHash: c.headers[number].Hash(), Index: index, TxHash: common.Hash{byte(index)}}
+}
+
+func newFakeChain(n int) *fakeChain {
+	chain := &fakeChain{headers: []*types.Header{{Number: big.NewInt(0)}}}
+	chain.extend(0, n, "a")
+	return chain
+}
+
+func TestConfirmerWaitsForDepth(t *testing.T) {
+	chain := newFakeChain(3)
+	confirmer := NewConfirmer[string](2, chain)
+	if _, err := confirmer.AddHead(context.Background(), chain.head()); err != nil {
+		t.Fatal(err)
+	}
+
+	if update := confirmer.AddLog(chain.logAt(3, 0), "job"); len(update.Confirmed) != 0 {
+		t.Fatalf("expected the log to wait for 2 blocks, got %+v", update)
+	}
+	// the node may send the same log twice, e.g. from a backfill and a subscription
+	confirmer.AddLog(chain.logAt(3, 0), "job")
+	chain.extend(3, 1, "a")
+	if update, _ := confirmer.AddHead(context.Background(), chain.head()); len(update.Confirmed) != 0 {
+		t.Fatalf("expected the log to wait for 1 more block, got %+v", update)
+	}
+	chain.extend(4, 1, "a")
+	update, err := confirmer.AddHead(context.Background(), chain.head())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(update.Confirmed) != 1 || update.Confirmed[0].Value != "job" {
+		t.Fatalf("expected the log to be confirmed once, got %+v", update)
+	}
+}
+
+func TestConfirmerDropsPendingLogsOfReplacedBlocks(t *testing.T) {
+	chain := newFakeChain(3)
+	confirmer := NewConfirmer[string](2, chain)
+	confirmer.AddHead(context.Background(), chain.head())
+	confirmer.AddLog(chain.logAt(3, 0), "old")
+
+	// block 3 is replaced, and the log is not in the new chain
+	chain.extend(2, 3, "b")
+	update, err := confirmer.AddHead(context.Background(), chain.head())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update.Reorged || update.ForkPoint != 2 {
+		t.Errorf("expected a reorg from block 2, got %+v", update)
+	}
+	if len(update.Confirmed) != 0 || len(update.Removed) != 0 {
+		t.Errorf("expected the pending log to be dropped silently, got %+v", update)
+	}
+}
+
+func TestConfirmerRemovesAndReconfirmsAfterDeepReorg(t *testing.T) {
+	chain := newFakeChain(5)
+	confirmer := NewConfirmer[string](1, chain)
+	confirmer.AddHead(context.Background(), chain.head())
+	if update := confirmer.AddLog(chain.logAt(3, 0), "job"); len(update.Confirmed) != 1 {
+		t.Fatalf("expected the log to be confirmed, got %+v", update)
+	}
+
+	// a reorg deeper than the confirmation depth, which the new head reveals
+	// without the node sending the removed log
+	chain.extend(2, 4, "b")
+	update, err := confirmer.AddHead(context.Background(), chain.head())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update.Reorged || update.ForkPoint != 2 || len(update.Removed) != 1 || update.Removed[0].Value != "job" {
+		t.Fatalf("expected the confirmed log to be removed by a reorg from block 2, got %+v", update)
+	}
+
+	// the new chain includes the log again, in a later block
+	if update := confirmer.AddLog(chain.logAt(4, 0), "job"); len(update.Confirmed) != 1 {
+		t.Fatalf("expected the re-included log to be confirmed, got %+v", update)
+	}
+	removed := chain.logAt(4, 0)
+	removed.Removed = true
+	if update := confirmer.AddLog(removed, "job"); len(update.Removed) != 1 {
+		t.Fatalf("expected the node's removed log to remove the event, got %+v", update)
+	}
+}
+
+func TestConfirmerKeepsLogsThatArriveBeforeTheirHead(t *testing.T) {
+	chain := newFakeChain(5)
+	confirmer := NewConfirmer[string](1, chain)
+	confirmer.AddHead(context.Background(), chain.head())
+
+	// the log of the new chain comes before its head, while block 4 is still the old one
+	chain.extend(2, 4, "b")
+	if update := confirmer.AddLog(chain.logAt(4, 0), "new"); len(update.Confirmed) != 0 {
+		t.Fatalf("expected the log of a block that is not canonical yet to wait, got %+v", update)
+	}
+	update, err := confirmer.AddHead(context.Background(), chain.head())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update.Reorged || len(update.Confirmed) != 1 || update.Confirmed[0].Value != "new" {
+		t.Fatalf("expected the log to be confirmed with its head, got %+v", update)
+	}
+}
+
+func TestConfirmerReconfirmsWhenTheChainSwitchesBack(t *testing.T) {
+	chain := newFakeChain(5)
+	old := append([]*types.Header(nil), chain.headers...)
+	confirmer := NewConfirmer[string](1, chain)
+	confirmer.AddHead(context.Background(), chain.head())
+	if update := confirmer.AddLog(chain.logAt(3, 0), "job"); len(update.Confirmed) != 1 {
+		t.Fatalf("expected the log to be confirmed, got %+v", update)
+	}
+
+	chain.extend(2, 4, "b")
+	if update, _ := confirmer.AddHead(context.Background(), chain.head()); len(update.Removed) != 1 {
+		t.Fatalf("expected the log to be removed, got %+v", update)
+	}
+
+	// the old chain wins after all, and grows past the new one
+	chain.headers = old
+	chain.extend(5, 2, "a")
+	update, err := confirmer.AddHead(context.Background(), chain.head())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(update.Confirmed) != 1 || update.Confirmed[0].Value != "job" {
+		t.Fatalf("expected the log to be confirmed again, got %+v", update)
+	}
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TaskResponseVersion is the version of the IKeeperNetworkTaskManager.TaskResponse
+// layout. KeeperNetworkTaskManager.taskResponseDigest only ever encodes its
+// current struct, so only responses of this version verify on chain. It is
+// bumped whenever the struct changes.
+const TaskResponseVersion uint8 = 3
+
+// Execution statuses reported in TaskResponse.Status.
+const (
+	TaskStatusSucceeded uint8 = 1
+	TaskStatusFailed    uint8 = 2
+	TaskStatusSkipped   uint8 = 3
+)
+
+// Upkeep transaction statuses reported in TaskResponse.TxStatus.
+const (
+	UpkeepTxNone      uint8 = 0
+	UpkeepTxSucceeded uint8 = 1
+	UpkeepTxReverted  uint8 = 2
+)
+
+// TaskResponse mirrors IKeeperNetworkTaskManager.TaskResponse. It is what
+// keepers sign and what the aggregator submits through respondToTask.
+type TaskResponse struct {
+	Version         uint8
+	ReferenceTaskId uint32
+	JobId           uint32
+	// Status is one of the TaskStatus constants.
+	Status uint8
+	// ResultHash is the keccak256 hash of the job result.
+	ResultHash [32]byte
+	// TxHash and TxStatus are zero in the responses the quorum signs:
+	// operators attest to the call an upkeep job returned, through ResultHash,
+	// and only the operator the task is assigned to submits it, so no
+	// transaction is common to them. Once its transaction is included, that
+	// operator signs its response again with the transaction's hash and
+	// UpkeepTxSucceeded or UpkeepTxReverted, which the aggregator records
+	// through recordUpkeep, see IsUpkeepResponse.
+	TxHash   [32]byte
+	TxStatus uint8
+	// CheckBlock is the block at which the job's condition check held, and
+	// CheckResultHash the keccak256 hash of what the check returned there.
+	// Both are zero for jobs without a condition.
+	CheckBlock      uint64
+	CheckResultHash [32]byte
+}
+
+// TaskResponseDomain binds a task response digest to one chain and one task
+// manager contract, so a signature cannot be replayed on another deployment.
+type TaskResponseDomain struct {
+	ChainId     *big.Int
+	TaskManager common.Address
+}
+
+// taskResponseDomainTypehash must match TASK_RESPONSE_DOMAIN_TYPEHASH in KeeperNetworkTaskManager.sol
+var taskResponseDomainTypehash = crypto.Keccak256Hash([]byte("KeeperNetworkTaskResponse(uint256 chainId,address taskManager)"))
+
+// AbiEncodeTaskResponse returns abi.encode(taskResponse). It fails for responses
+// of any version other than TaskResponseVersion.
+func AbiEncodeTaskResponse(h *TaskResponse) ([]byte, error) {
+	if h.Version != TaskResponseVersion {
+		return nil, fmt.Errorf("unsupported task response version %d", h.Version)
+	}
+	// The order here has to match the field ordering of IKeeperNetworkTaskManager.TaskResponse
+	taskResponseType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+		{Name: "version", Type: "uint8"},
+		{Name: "referenceTaskId", Type: "uint32"},
+		{Name: "jobId", Type: "uint32"},
+		{Name: "status", Type: "uint8"},
+		{Name: "resultHash", Type: "bytes32"},
+		{Name: "txHash", Type: "bytes32"},
+		{Name: "txStatus", Type: "uint8"},
+		{Name: "checkBlock", Type: "uint64"},
+		{Name: "checkResultHash", Type: "bytes32"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	arguments := abi.Arguments{
+		{
+			Type: taskResponseType,
+		},
+	}
+	return arguments.Pack(struct {
+		Version         uint8
+		ReferenceTaskId uint32
+		JobId           uint32
+		Status          uint8
+		ResultHash      [32]byte
+		TxHash          [32]byte
+		TxStatus        uint8
+		CheckBlock      uint64
+		CheckResultHash [32]byte
+	}{h.Version, h.ReferenceTaskId, h.JobId, h.Status, h.ResultHash, h.TxHash, h.TxStatus, h.CheckBlock, h.CheckResultHash})
+}
+
+// IsUpkeepResponse reports whether the response is the one the operator an
+// upkeep task is assigned to signs for its transaction, rather than one for
+// the quorum.
+func (h *TaskResponse) IsUpkeepResponse() bool {
+	return h.TxHash != [32]byte{}
+}
+
+// GetTaskResponseDigest returns the hash of the TaskResponse, which is what operators sign over.
+// It is keccak256(abi.encode(domainSeparator, keccak256(abi.encode(taskResponse)))), the same
+// value KeeperNetworkTaskManager.taskResponseDigest computes onchain.
+func GetTaskResponseDigest(domain TaskResponseDomain, h *TaskResponse) ([32]byte, error) {
+	encodedTaskResponse, err := AbiEncodeTaskResponse(h)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	domainSeparator, err := domain.separator()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return crypto.Keccak256Hash(domainSeparator[:], crypto.Keccak256(encodedTaskResponse)), nil
+}
+
+func (d TaskResponseDomain) separator() ([32]byte, error) {
+	if d.ChainId == nil {
+		return [32]byte{}, fmt.Errorf("task response domain is missing the chain id")
+	}
+	arguments := abi.Arguments{
+		{Type: mustNewType("bytes32")},
+		{Type: mustNewType("uint256")},
+		{Type: mustNewType("address")},
+	}
+	encoded, err := arguments.Pack(taskResponseDomainTypehash, d.ChainId, d.TaskManager)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+func mustNewType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The vectors below are what KeeperNetworkTaskManager computes for the same
+// response with abi.encode: nine static fields, each padded to a 32 byte word.
+var testTaskResponse = TaskResponse{
+	Version:         TaskResponseVersion,
+	ReferenceTaskId: 7,
+	JobId:           3,
+	Status:          TaskStatusSucceeded,
+	ResultHash:      common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111"),
+	CheckBlock:      1234,
+	CheckResultHash: common.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222"),
+}
+
+func TestAbiEncodeTaskResponseMatchesContract(t *testing.T) {
+	encoded, err := AbiEncodeTaskResponse(&testTaskResponse)
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"0000000000000000000000000000000000000000000000000000000000000003"+
+		"0000000000000000000000000000000000000000000000000000000000000007"+
+		"0000000000000000000000000000000000000000000000000000000000000003"+
+		"0000000000000000000000000000000000000000000000000000000000000001"+
+		"1111111111111111111111111111111111111111111111111111111111111111"+
+		"0000000000000000000000000000000000000000000000000000000000000000"+
+		"0000000000000000000000000000000000000000000000000000000000000000"+
+		"00000000000000000000000000000000000000000000000000000000000004d2"+
+		"2222222222222222222222222222222222222222222222222222222222222222",
+		hex.EncodeToString(encoded))
+}
+
+func TestGetTaskResponseDigestMatchesContract(t *testing.T) {
+	domain := TaskResponseDomain{
+		ChainId:     big.NewInt(31337),
+		TaskManager: common.HexToAddress("0x0000000000000000000000000000000000001234"),
+	}
+	digest, err := GetTaskResponseDigest(domain, &testTaskResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "3e65eeb46bad740a8b4aea690f56c0ef3313caf6c7790f287f84d7411597cbef", hex.EncodeToString(digest[:]))
+}
+
+func TestAbiEncodeTaskResponseRejectsOtherVersions(t *testing.T) {
+	for _, version := range []uint8{0, 1, TaskResponseVersion + 1} {
+		taskResponse := testTaskResponse
+		taskResponse.Version = version
+		_, err := AbiEncodeTaskResponse(&taskResponse)
+		assert.Error(t, err, "version %d", version)
+	}
+}
@@ -4,76 +4,33 @@ import (
 	"math/big"
 
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
-	cstaskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/IncredibleSquaringTaskManager"
-	"github.com/ethereum/go-ethereum/accounts/abi"
-	"golang.org/x/crypto/sha3"
+	taskmanager "github.com/Layr-Labs/incredible-squaring-avs/contracts/bindings/KeeperNetworkTaskManager"
 )
 
-// this hardcodes abi.encode() for cstaskmanager.IIncredibleSquaringTaskManagerTaskResponse
-// unclear why abigen doesn't provide this out of the box...
-func AbiEncodeTaskResponse(h *cstaskmanager.IIncredibleSquaringTaskManagerTaskResponse) ([]byte, error) {
-
-	// The order here has to match the field ordering of cstaskmanager.IIncredibleSquaringTaskManagerTaskResponse
-	taskResponseType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
-		{
-			Name: "referenceTaskIndex",
-			Type: "uint32",
-		},
-		{
-			Name: "numberSquared",
-			Type: "uint256",
-		},
-	})
-	if err != nil {
-		return nil, err
-	}
-	arguments := abi.Arguments{
-		{
-			Type: taskResponseType,
-		},
-	}
-
-	bytes, err := arguments.Pack(h)
-	if err != nil {
-		return nil, err
-	}
-
-	return bytes, nil
-}
-
-// GetTaskResponseDigest returns the hash of the TaskResponse, which is what operators sign over
-func GetTaskResponseDigest(h *cstaskmanager.IIncredibleSquaringTaskManagerTaskResponse) ([32]byte, error) {
-
-	encodeTaskResponseByte, err := AbiEncodeTaskResponse(h)
-	if err != nil {
-		return [32]byte{}, err
-	}
-
-	var taskResponseDigest [32]byte
-	hasher := sha3.NewLegacyKeccak256()
-	hasher.Write(encodeTaskResponseByte)
-	copy(taskResponseDigest[:], hasher.Sum(nil)[:32])
-
-	return taskResponseDigest, nil
-}
-
 // BINDING UTILS - conversion from contract structs to golang structs
 
 // BN254.sol is a library, so bindings for G1 Points and G2 Points are only generated
 // in every contract that imports that library. Thus the output here will need to be
 // type casted if G1Point is needed to interface with another contract (eg: BLSPublicKeyCompendium.sol)
-func ConvertToBN254G1Point(input *bls.G1Point) cstaskmanager.BN254G1Point {
-	output := cstaskmanager.BN254G1Point{
+func ConvertToBN254G1Point(input *bls.G1Point) taskmanager.BN254G1Point {
+	output := taskmanager.BN254G1Point{
 		X: input.X.BigInt(big.NewInt(0)),
 		Y: input.Y.BigInt(big.NewInt(0)),
 	}
 	return output
 }
 
-func ConvertToBN254G2Point(input *bls.G2Point) cstaskmanager.BN254G2Point {
-	output := cstaskmanager.BN254G2Point{
-		X: [2]*big.Int{input.X.A1.BigInt(big.NewInt(0)), input.X.A0.BigInt(big.NewInt(0))},
-		Y: [2]*big.Int{input.Y.A1.BigInt(big.NewInt(0)), input.Y.A0.BigInt(big.NewInt(0))},
+// ConvertToTaskManagerTaskResponse converts a TaskResponse to the struct respondToTask takes.
+func ConvertToTaskManagerTaskResponse(input *TaskResponse) taskmanager.IKeeperNetworkTaskManagerTaskResponse {
+	return taskmanager.IKeeperNetworkTaskManagerTaskResponse{
+		Version:         input.Version,
+		ReferenceTaskId: input.ReferenceTaskId,
+		JobId:           input.JobId,
+		Status:          input.Status,
+		ResultHash:      input.ResultHash,
+		TxHash:          input.TxHash,
+		TxStatus:        input.TxStatus,
+		CheckBlock:      input.CheckBlock,
+		CheckResultHash: input.CheckResultHash,
 	}
-	return output
 }
@@ -0,0 +1,363 @@
+// Package aggregatorclient delivers a keeper's signed task responses to the
+// aggregator. Responses are written to an on-disk outbox before they are sent
+// and stay there until the aggregator accepts or rejects them, or their task's
+// response window is over, so they survive keeper restarts and aggregator
+// outages.
+package aggregatorclient
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/rpc"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/incredible-squaring-avs/aggregator"
+)
+
+const (
+	processSignedTaskResponseMethod   = "Aggregator.ProcessSignedTaskResponse"
+	processSignedUpkeepResponseMethod = "Aggregator.ProcessSignedUpkeepResponse"
+	// the status line net/rpc's HTTP handler answers a CONNECT with
+	rpcConnectedStatus = "200 Connected to Go RPC"
+
+	defaultMaxOutboxSize      = 1000
+	defaultCallTimeout        = 10 * time.Second
+	defaultMaxAttempts        = 5
+	defaultInitialBackoff     = 500 * time.Millisecond
+	defaultMaxBackoff         = 30 * time.Second
+	defaultRedeliveryInterval = 30 * time.Second
+	// the aggregator expires tasks after their challenge window of 100 blocks
+	defaultResponseWindow = 100 * 12 * time.Second
+)
+
+var (
+	// ErrRejected means the aggregator refused the response for good, e.g.
+	// because its signature is invalid or its task is closed. It has been
+	// removed from the outbox.
+	ErrRejected = errors.New("aggregator rejected the signed task response")
+	// ErrNotDelivered means the response could not be delivered yet. It stays
+	// in the outbox and Run keeps trying to deliver it.
+	ErrNotDelivered = errors.New("signed task response not delivered yet")
+)
+
+type Config struct {
+	// AggregatorIpPortAddr is the address of the aggregator's rpc server.
+	AggregatorIpPortAddr string
+	// OutboxDir holds the responses that have not been delivered yet.
+	OutboxDir string
+	// MaxOutboxSize bounds the number of undelivered responses kept. The
+	// oldest are dropped first.
+	MaxOutboxSize int
+	// CallTimeout bounds a single delivery attempt, dialing included.
+	CallTimeout time.Duration
+	// MaxAttempts is how many times SendSignedTaskResponseToAggregator tries
+	// to deliver a response before it leaves it to Run.
+	MaxAttempts int
+	// The wait between attempts starts around InitialBackoff and doubles up
+	// to MaxBackoff, with jitter so that keepers do not retry in lockstep.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RedeliveryInterval is how often Run retries the responses left in the outbox.
+	RedeliveryInterval time.Duration
+	// ResponseWindow is how long tasks accept responses. Run drops the
+	// responses that were queued longer ago.
+	ResponseWindow time.Duration
+}
+
+type Client struct {
+	config Config
+	outbox *outbox
+
+	rpcClientMu sync.Mutex
+	rpcClient   *rpc.Client
+
+	// names of the outbox entries being delivered, so Run and
+	// SendSignedTaskResponseToAggregator do not deliver the same one at once
+	inFlightMu sync.Mutex
+	inFlight   map[string]bool
+}
+
+func NewClient(config Config) (*Client, error) {
+	if config.AggregatorIpPortAddr == "" {
+		return nil, errors.New("aggregatorclient: aggregator address is required")
+	}
+	if config.OutboxDir == "" {
+		return nil, errors.New("aggregatorclient: outbox dir is required")
+	}
+	if config.MaxOutboxSize == 0 {
+		config.MaxOutboxSize = defaultMaxOutboxSize
+	}
+	if config.CallTimeout == 0 {
+		config.CallTimeout = defaultCallTimeout
+	}
+	if config.MaxAttempts == 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+	if config.InitialBackoff == 0 {
+		config.InitialBackoff = defaultInitialBackoff
+	}
+	if config.MaxBackoff == 0 {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+	if config.RedeliveryInterval == 0 {
+		config.RedeliveryInterval = defaultRedeliveryInterval
+	}
+	if config.ResponseWindow == 0 {
+		config.ResponseWindow = defaultResponseWindow
+	}
+	outbox, err := newOutbox(config.OutboxDir, config.MaxOutboxSize)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{config: config, outbox: outbox, inFlight: make(map[string]bool)}, nil
+}
+
+// SendSignedTaskResponseToAggregator queues the response in the outbox and
+// tries to deliver it, backing off between attempts. It returns nil once the
+// aggregator accepted the response, an error wrapping ErrRejected if the
+// aggregator refused it, and one wrapping ErrNotDelivered if it is still queued.
+// Sending a response for a task the operator has one queued for already
+// delivers the queued one, or leaves it to whoever is delivering it. Upkeep
+// responses, see core.TaskResponse.IsUpkeepResponse, go to the aggregator's
+// ProcessSignedUpkeepResponse and are queued apart from the task's response.
+func (c *Client) SendSignedTaskResponseToAggregator(ctx context.Context, signedTaskResponse *aggregator.SignedTaskResponse) error {
+	c.inFlightMu.Lock()
+	name, err := c.outbox.add(signedTaskResponse)
+	if err != nil {
+		c.inFlightMu.Unlock()
+		return fmt.Errorf("failed to queue signed task response: %w", err)
+	}
+	if c.inFlight[name] {
+		c.inFlightMu.Unlock()
+		return fmt.Errorf("%w: it is being delivered already", ErrNotDelivered)
+	}
+	c.inFlight[name] = true
+	c.inFlightMu.Unlock()
+	defer c.release(name)
+
+	var lastErr error
+	for attempt := 0; attempt < c.config.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w: %v", ErrNotDelivered, ctx.Err())
+			case <-time.After(c.backoff(attempt - 1)):
+			}
+		}
+		lastErr = c.deliver(ctx, name, signedTaskResponse)
+		if lastErr == nil || errors.Is(lastErr, ErrRejected) {
+			return lastErr
+		}
+		log.Printf("Failed to deliver signed task response for task %d (attempt %d/%d): %v",
+			signedTaskResponse.TaskResponse.ReferenceTaskId, attempt+1, c.config.MaxAttempts, lastErr)
+	}
+	return fmt.Errorf("%w after %d attempts: %v", ErrNotDelivered, c.config.MaxAttempts, lastErr)
+}
+
+// Run redelivers the responses left in the outbox, including the ones queued
+// before the keeper restarted, every RedeliveryInterval until ctx is done.
+func (c *Client) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.config.RedeliveryInterval)
+	defer ticker.Stop()
+	for {
+		c.redeliver(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close closes the connection to the aggregator. Undelivered responses stay in the outbox.
+func (c *Client) Close() error {
+	c.rpcClientMu.Lock()
+	defer c.rpcClientMu.Unlock()
+	if c.rpcClient == nil {
+		return nil
+	}
+	err := c.rpcClient.Close()
+	c.rpcClient = nil
+	return err
+}
+
+// redeliver makes one attempt at delivering each response left in the outbox.
+// Responses whose task the aggregator does not know are dropped: they were
+// retried when they were sent, so the aggregator will not learn of the task
+// anymore. So are the responses queued before the response window.
+func (c *Client) redeliver(ctx context.Context) {
+	entries, err := c.outbox.entries()
+	if err != nil {
+		log.Printf("Failed to read aggregator outbox: %v", err)
+		return
+	}
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return
+		}
+		taskId := entry.response.TaskResponse.ReferenceTaskId
+		if time.Since(entry.queuedAt) > c.config.ResponseWindow {
+			if err := c.outbox.delete(entry.name); err != nil {
+				log.Printf("Failed to remove signed task response from the outbox: %v", err)
+			}
+			log.Printf("Dropped queued signed task response for task %d, its response window is over", taskId)
+			continue
+		}
+		if !c.claim(entry.name) {
+			continue
+		}
+		err := c.call(ctx, entry.response)
+		if isTaskNotFound(err) {
+			if err := c.outbox.delete(entry.name); err != nil {
+				log.Printf("Failed to remove signed task response from the outbox: %v", err)
+			}
+			err = fmt.Errorf("%w: %v", ErrRejected, err)
+		} else {
+			err = c.settle(entry.name, err)
+		}
+		c.release(entry.name)
+		switch {
+		case err == nil:
+			log.Printf("Delivered queued signed task response for task %d", taskId)
+		case errors.Is(err, ErrRejected):
+			log.Printf("Dropped queued signed task response for task %d: %v", taskId, err)
+		default:
+			log.Printf("Failed to deliver queued signed task response for task %d: %v", taskId, err)
+		}
+	}
+}
+
+// deliver makes one attempt at delivering an outbox entry, and removes it
+// from the outbox if the aggregator accepted or rejected it.
+func (c *Client) deliver(ctx context.Context, name string, signedTaskResponse *aggregator.SignedTaskResponse) error {
+	return c.settle(name, c.call(ctx, signedTaskResponse))
+}
+
+// settle removes an outbox entry once the aggregator accepted or rejected it,
+// given the outcome of an attempt at delivering it.
+func (c *Client) settle(name string, err error) error {
+	if err != nil && aggregator.IsRetryableError(err) {
+		return err
+	}
+	if removeErr := c.outbox.delete(name); removeErr != nil {
+		log.Printf("Failed to remove signed task response from the outbox: %v", removeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrRejected, err)
+	}
+	return nil
+}
+
+func (c *Client) call(ctx context.Context, signedTaskResponse *aggregator.SignedTaskResponse) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.CallTimeout)
+	defer cancel()
+	rpcClient, err := c.getRpcClient(ctx)
+	if err != nil {
+		return err
+	}
+	method := processSignedTaskResponseMethod
+	if signedTaskResponse.TaskResponse.IsUpkeepResponse() {
+		method = processSignedUpkeepResponseMethod
+	}
+	var reply bool
+	call := rpcClient.Go(method, signedTaskResponse, &reply, make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+	case <-ctx.Done():
+		// the reply may still arrive on this connection, so drop it rather than reuse it
+		c.resetRpcClient(rpcClient)
+		return ctx.Err()
+	}
+	var serverErr rpc.ServerError
+	if call.Error != nil && !errors.As(call.Error, &serverErr) {
+		// the connection is broken, redial on the next call
+		c.resetRpcClient(rpcClient)
+	}
+	return call.Error
+}
+
+func isTaskNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), aggregator.TaskNotFoundError500.Error())
+}
+
+func (c *Client) getRpcClient(ctx context.Context) (*rpc.Client, error) {
+	c.rpcClientMu.Lock()
+	defer c.rpcClientMu.Unlock()
+	if c.rpcClient != nil {
+		return c.rpcClient, nil
+	}
+	rpcClient, err := dialHTTP(ctx, c.config.AggregatorIpPortAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial aggregator at %s: %w", c.config.AggregatorIpPortAddr, err)
+	}
+	c.rpcClient = rpcClient
+	return rpcClient, nil
+}
+
+func (c *Client) resetRpcClient(rpcClient *rpc.Client) {
+	c.rpcClientMu.Lock()
+	defer c.rpcClientMu.Unlock()
+	if c.rpcClient == rpcClient {
+		c.rpcClient.Close()
+		c.rpcClient = nil
+	}
+}
+
+func (c *Client) claim(name string) bool {
+	c.inFlightMu.Lock()
+	defer c.inFlightMu.Unlock()
+	if c.inFlight[name] {
+		return false
+	}
+	c.inFlight[name] = true
+	return true
+}
+
+func (c *Client) release(name string) {
+	c.inFlightMu.Lock()
+	defer c.inFlightMu.Unlock()
+	delete(c.inFlight, name)
+}
+
+// backoff returns the wait before retry number retry (starting at 0): a random
+// duration between half and all of InitialBackoff*2^retry, capped at MaxBackoff.
+func (c *Client) backoff(retry int) time.Duration {
+	d := c.config.InitialBackoff
+	for i := 0; i < retry && d < c.config.MaxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, c.config.MaxBackoff)
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// dialHTTP is rpc.DialHTTP with a context, which the net/rpc package does not offer.
+func dialHTTP(ctx context.Context, address string) (*rpc.Client, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	io.WriteString(conn, "CONNECT "+rpc.DefaultRPCPath+" HTTP/1.0\n\n")
+	resp, err := http.ReadResponse(bufio.NewReader(conn), &http.Request{Method: "CONNECT"})
+	if err == nil && resp.Status != rpcConnectedStatus {
+		err = errors.New("unexpected HTTP response: " + resp.Status)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+	return rpc.NewClient(conn), nil
+}
@@ -0,0 +1,306 @@
+package aggregatorclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/incredible-squaring-avs/aggregator"
+	"github.com/Layr-Labs/incredible-squaring-avs/core"
+)
+
+// fakeAggregator answers ProcessSignedTaskResponse with the queued errors, then accepts.
+type fakeAggregator struct {
+	mu       sync.Mutex
+	errs     []error
+	received []uint32
+	upkeeps  []uint32
+}
+
+func (a *fakeAggregator) ProcessSignedTaskResponse(signedTaskResponse *aggregator.SignedTaskResponse, reply *bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.received = append(a.received, signedTaskResponse.TaskResponse.ReferenceTaskId)
+	if len(a.errs) > 0 {
+		err := a.errs[0]
+		a.errs = a.errs[1:]
+		return err
+	}
+	*reply = true
+	return nil
+}
+
+func (a *fakeAggregator) ProcessSignedUpkeepResponse(signedUpkeepResponse *aggregator.SignedTaskResponse, reply *bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.upkeeps = append(a.upkeeps, signedUpkeepResponse.TaskResponse.ReferenceTaskId)
+	*reply = true
+	return nil
+}
+
+func (a *fakeAggregator) calls() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.received)
+}
+
+// startFakeAggregator serves fake on a new listener and returns its address.
+func startFakeAggregator(t *testing.T, fake *fakeAggregator) string {
+	t.Helper()
+	server := rpc.NewServer()
+	if err := server.RegisterName("Aggregator", fake); err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(rpc.DefaultRPCPath, server)
+	httpServer := &http.Server{Handler: mux}
+	go httpServer.Serve(listener)
+	t.Cleanup(func() { httpServer.Close() })
+	return listener.Addr().String()
+}
+
+func testSignedTaskResponse(t *testing.T, taskId uint32) *aggregator.SignedTaskResponse {
+	t.Helper()
+	keyPair, err := bls.NewKeyPairFromString("12248929636257230549931416853095037629726205319386239410403476017439825112537")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &aggregator.SignedTaskResponse{
+		TaskResponse: core.TaskResponse{Version: core.TaskResponseVersion, ReferenceTaskId: taskId, JobId: 1, Status: core.TaskStatusSucceeded},
+		BlsSignature: *keyPair.SignMessage([32]byte{byte(taskId)}),
+	}
+}
+
+func testConfig(t *testing.T, addr string) Config {
+	return Config{
+		AggregatorIpPortAddr: addr,
+		OutboxDir:            t.TempDir(),
+		MaxAttempts:          3,
+		InitialBackoff:       time.Millisecond,
+		MaxBackoff:           time.Millisecond,
+		CallTimeout:          time.Second,
+	}
+}
+
+func TestSendRetriesTransientErrors(t *testing.T) {
+	fake := &fakeAggregator{errs: []error{errors.New("500. Task not found")}}
+	client, err := NewClient(testConfig(t, startFakeAggregator(t, fake)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.SendSignedTaskResponseToAggregator(context.Background(), testSignedTaskResponse(t, 1)); err != nil {
+		t.Fatalf("expected delivery, got %v", err)
+	}
+	if fake.calls() != 2 {
+		t.Errorf("expected 2 calls, got %d", fake.calls())
+	}
+	if entries, _ := client.outbox.entries(); len(entries) != 0 {
+		t.Errorf("expected an empty outbox, got %d entries", len(entries))
+	}
+}
+
+func TestSendDoesNotRetryRejections(t *testing.T) {
+	fake := &fakeAggregator{errs: []error{errors.New("400. Signature verification failed")}}
+	client, err := NewClient(testConfig(t, startFakeAggregator(t, fake)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	err = client.SendSignedTaskResponseToAggregator(context.Background(), testSignedTaskResponse(t, 1))
+	if !errors.Is(err, ErrRejected) {
+		t.Fatalf("expected ErrRejected, got %v", err)
+	}
+	if fake.calls() != 1 {
+		t.Errorf("expected 1 call, got %d", fake.calls())
+	}
+	if entries, _ := client.outbox.entries(); len(entries) != 0 {
+		t.Errorf("expected rejected response to leave the outbox, got %d entries", len(entries))
+	}
+}
+
+func TestSendDeliversUpkeepResponsesSeparately(t *testing.T) {
+	fake := &fakeAggregator{}
+	client, err := NewClient(testConfig(t, startFakeAggregator(t, fake)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// the task's response stays queued, the upkeep response is queued beside it
+	taskName, err := client.outbox.add(testSignedTaskResponse(t, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	upkeepResponse := testSignedTaskResponse(t, 1)
+	upkeepResponse.TaskResponse.TxHash = [32]byte{1}
+	upkeepResponse.TaskResponse.TxStatus = core.UpkeepTxSucceeded
+	upkeepName, err := client.outbox.add(upkeepResponse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upkeepName == taskName {
+		t.Fatalf("expected the upkeep response to get its own entry, got %s", upkeepName)
+	}
+
+	if err := client.SendSignedTaskResponseToAggregator(context.Background(), upkeepResponse); err != nil {
+		t.Fatalf("expected delivery, got %v", err)
+	}
+	if fake.calls() != 0 || fmt.Sprint(fake.upkeeps) != "[1]" {
+		t.Errorf("expected one upkeep call for task 1, got %d task response calls and upkeeps %v", fake.calls(), fake.upkeeps)
+	}
+	if entries, _ := client.outbox.entries(); len(entries) != 1 || entries[0].name != taskName {
+		t.Errorf("expected only the task's response to be left in the outbox, got %v", entries)
+	}
+}
+
+func TestUndeliveredResponsesSurviveRestart(t *testing.T) {
+	// nothing listens on this address yet
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	config := testConfig(t, addr)
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.SendSignedTaskResponseToAggregator(context.Background(), testSignedTaskResponse(t, 7))
+	if !errors.Is(err, ErrNotDelivered) {
+		t.Fatalf("expected ErrNotDelivered, got %v", err)
+	}
+	client.Close()
+
+	// the aggregator comes up on the same address, and the keeper restarts
+	listener, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Skipf("cannot listen on %s again: %v", addr, err)
+	}
+	fake := &fakeAggregator{}
+	server := rpc.NewServer()
+	server.RegisterName("Aggregator", fake)
+	mux := http.NewServeMux()
+	mux.Handle(rpc.DefaultRPCPath, server)
+	httpServer := &http.Server{Handler: mux}
+	go httpServer.Serve(listener)
+	defer httpServer.Close()
+
+	client, err = NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	client.redeliver(context.Background())
+	if fake.calls() != 1 || fake.received[0] != 7 {
+		t.Fatalf("expected task 7 to be redelivered, got %v", fake.received)
+	}
+	if entries, _ := client.outbox.entries(); len(entries) != 0 {
+		t.Errorf("expected an empty outbox, got %d entries", len(entries))
+	}
+}
+
+func TestOutboxDropsOldestWhenFull(t *testing.T) {
+	outbox, err := newOutbox(t.TempDir(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for taskId := uint32(1); taskId <= 3; taskId++ {
+		if _, err := outbox.add(testSignedTaskResponse(t, taskId)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err := outbox.entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	for i, taskId := range []uint32{2, 3} {
+		if got := entries[i].response.TaskResponse.ReferenceTaskId; got != taskId {
+			t.Errorf("entry %d: expected task %d, got %d", i, taskId, got)
+		}
+	}
+}
+
+func TestOutboxKeepsOneResponsePerTaskAndOperator(t *testing.T) {
+	outbox, err := newOutbox(t.TempDir(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := outbox.add(testSignedTaskResponse(t, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the task was resent, and so was its response
+	again, err := outbox.add(testSignedTaskResponse(t, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != first {
+		t.Errorf("expected the queued entry %s, got %s", first, again)
+	}
+	other := testSignedTaskResponse(t, 1)
+	other.OperatorId[0] = 1
+	if _, err := outbox.add(other); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := outbox.entries(); len(entries) != 2 {
+		t.Errorf("expected 2 entries, got %d", len(entries))
+	}
+}
+
+func TestRedeliverGoesPastFailingResponses(t *testing.T) {
+	fake := &fakeAggregator{errs: []error{
+		errors.New("500. Failed to verify signature"),
+		errors.New("500. Task not found: task 2"),
+	}}
+	client, err := NewClient(testConfig(t, startFakeAggregator(t, fake)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// queued before the response window
+	name, err := client.outbox.add(testSignedTaskResponse(t, 9))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := fmt.Sprintf("%020d%s", time.Now().Add(-2*defaultResponseWindow).UnixNano(), name[20:])
+	if err := os.Rename(filepath.Join(client.config.OutboxDir, name), filepath.Join(client.config.OutboxDir, stale)); err != nil {
+		t.Fatal(err)
+	}
+	for taskId := uint32(1); taskId <= 3; taskId++ {
+		if _, err := client.outbox.add(testSignedTaskResponse(t, taskId)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client.redeliver(context.Background())
+	if fmt.Sprint(fake.received) != "[1 2 3]" {
+		t.Errorf("expected tasks 1, 2 and 3 to be tried, got %v", fake.received)
+	}
+	// task 1 failed transiently, task 2 is unknown to the aggregator and task 3 was delivered
+	entries, _ := client.outbox.entries()
+	if len(entries) != 1 || entries[0].response.TaskResponse.ReferenceTaskId != 1 {
+		t.Errorf("expected only task 1 to be left in the outbox, got %v", entries)
+	}
+}
@@ -0,0 +1,178 @@
+package aggregatorclient
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/incredible-squaring-avs/aggregator"
+)
+
+const (
+	outboxFileExt = ".gob"
+	// marks the entries of upkeep responses, which are queued alongside
+	// the operator's response to the same task
+	upkeepResponseKind = "-upkeep"
+)
+
+// outbox keeps signed task responses on disk until the aggregator has
+// accepted or rejected them. Each response is one file named after the time
+// it was queued, its task and its operator, so that a directory listing is
+// oldest first and an operator has at most one queued response per task, and
+// one upkeep response.
+type outbox struct {
+	dir     string
+	maxSize int
+
+	mu sync.Mutex
+}
+
+type outboxEntry struct {
+	name     string
+	queuedAt time.Time
+	response *aggregator.SignedTaskResponse
+}
+
+func newOutbox(dir string, maxSize int) (*outbox, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &outbox{dir: dir, maxSize: maxSize}, nil
+}
+
+// add queues a response and returns its entry name. If the operator already
+// has a response queued for the task, that entry's name is returned instead
+// and nothing is written. When the outbox is full the oldest responses are
+// dropped, since their tasks are the most likely to have expired already.
+func (o *outbox) add(response *aggregator.SignedTaskResponse) (string, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(response); err != nil {
+		return "", err
+	}
+	kind := ""
+	if response.TaskResponse.IsUpkeepResponse() {
+		kind = upkeepResponseKind
+	}
+	key := fmt.Sprintf("-%010d-%x%s%s", response.TaskResponse.ReferenceTaskId, response.OperatorId, kind, outboxFileExt)
+	name := fmt.Sprintf("%020d%s", time.Now().UnixNano(), key)
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	names, err := o.names()
+	if err != nil {
+		return "", err
+	}
+	for _, queued := range names {
+		if strings.HasSuffix(queued, key) {
+			return queued, nil
+		}
+	}
+	for len(names) >= o.maxSize {
+		if err := o.remove(names[0]); err != nil {
+			return "", err
+		}
+		names = names[1:]
+	}
+	// written to a temporary file first, so a crash never leaves a partial entry behind
+	tmp, err := os.CreateTemp(o.dir, ".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(o.dir, name)); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// entries returns the queued responses, oldest first. Unreadable entries are dropped.
+func (o *outbox) entries() ([]outboxEntry, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	names, err := o.names()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]outboxEntry, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(o.dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		response := new(aggregator.SignedTaskResponse)
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(response); err != nil {
+			// it can never be delivered, so it would only take up space
+			if err := o.remove(name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		entries = append(entries, outboxEntry{name: name, queuedAt: queuedAt(name), response: response})
+	}
+	return entries, nil
+}
+
+// delete removes a delivered or rejected response. Deleting an entry that is
+// already gone is not an error.
+func (o *outbox) delete(name string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.remove(name)
+}
+
+// queuedAt reads the time an entry was queued from its name, the zero time if
+// the name does not start with one.
+func queuedAt(name string) time.Time {
+	prefix, _, _ := strings.Cut(name, "-")
+	nanos, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+func (o *outbox) names() ([]string, error) {
+	dirEntries, err := os.ReadDir(o.dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.Type().IsRegular() && strings.HasSuffix(name, outboxFileExt) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (o *outbox) remove(name string) error {
+	err := os.Remove(filepath.Join(o.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
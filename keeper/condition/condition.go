@@ -0,0 +1,251 @@
+// Package condition evaluates the read-only checks that gate the execution of
+// keeper jobs, in the style of checkUpkeep: the keeper evaluates a job's check
+// on every block or on an interval, and only runs the job once it holds. The
+// blocks a check is evaluated at are fixed by the task, so that the operators
+// running the same task find it holding at the same block.
+//
+// A job declares its check in its description:
+//
+//	check: call <address> <calldata or function signature>
+//	check: script
+//	check_every: block | <duration>
+//
+// "call" makes an eth_call to the contract at the evaluated block, e.g.
+// "check: call 0x5FbDB2315678afecb367f032d93F642f64180aa3 checkUpkeep()". The
+// check holds if the first word returned is non-zero, which is the case for a
+// view function returning true or (true, performData). "script" calls the
+// check entrypoint of the job's code, which must return true or false.
+// check_every defaults to block.
+package condition
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Kind is how a check is evaluated.
+type Kind string
+
+const (
+	KindCall   Kind = "call"
+	KindScript Kind = "script"
+)
+
+// Spec is the check a job declares.
+type Spec struct {
+	Kind Kind
+	// Target and Data are the contract and calldata of a KindCall check.
+	Target common.Address
+	Data   []byte
+	// Every is the interval between evaluations, or zero to evaluate on every
+	// new block.
+	Every time.Duration
+}
+
+// Parse reads the check from a job's description. It returns nil if the job
+// declares none.
+func Parse(description string) (*Spec, error) {
+	var check, every string
+	for _, line := range strings.Split(description, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "check":
+			check = strings.TrimSpace(value)
+		case "check_every":
+			every = strings.TrimSpace(value)
+		}
+	}
+	if check == "" {
+		if every != "" {
+			return nil, errors.New("check_every is set but the job declares no check")
+		}
+		return nil, nil
+	}
+
+	spec := &Spec{}
+	fields := strings.Fields(check)
+	switch Kind(strings.ToLower(fields[0])) {
+	case KindCall:
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid check %q, want \"call <address> <calldata or function signature>\"", check)
+		}
+		if !common.IsHexAddress(fields[1]) {
+			return nil, fmt.Errorf("check target %q is not an address", fields[1])
+		}
+		data, err := parseCalldata(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		spec.Kind, spec.Target, spec.Data = KindCall, common.HexToAddress(fields[1]), data
+	case KindScript:
+		if len(fields) != 1 {
+			return nil, fmt.Errorf("invalid check %q, want \"script\"", check)
+		}
+		spec.Kind = KindScript
+	default:
+		return nil, fmt.Errorf("unknown check %q, want \"call ...\" or \"script\"", check)
+	}
+
+	if every != "" && !strings.EqualFold(every, "block") {
+		d, err := time.ParseDuration(every)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid check_every %q, want \"block\" or a duration", every)
+		}
+		spec.Every = d
+	}
+	return spec, nil
+}
+
+// parseCalldata accepts 0x-prefixed calldata, or the signature of a function
+// without arguments, which is encoded as its selector.
+func parseCalldata(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "0x") {
+		data, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("check calldata: %w", err)
+		}
+		return data, nil
+	}
+	if !strings.HasSuffix(s, "()") {
+		return nil, fmt.Errorf("check calldata %q is neither hex nor a function signature without arguments", s)
+	}
+	return crypto.Keccak256([]byte(s))[:4], nil
+}
+
+// ChainReader reads the chain state checks are evaluated against.
+// *ethclient.Client satisfies it.
+type ChainReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// ErrWindowOver means the check did not hold at any block of its window.
+var ErrWindowOver = errors.New("the check did not hold within its window")
+
+// ScriptFunc runs the check entrypoint of a job's code at a block and returns
+// its output.
+type ScriptFunc func(ctx context.Context, block uint64) ([]byte, error)
+
+// Result is one evaluation of a check.
+type Result struct {
+	Holds bool
+	Block uint64
+	// Output is what the check returned: the contract's return data, or the
+	// output of the check entrypoint.
+	Output []byte
+}
+
+// Hash is the keccak256 hash of the check output, which goes into the task
+// response.
+func (r Result) Hash() common.Hash {
+	return crypto.Keccak256Hash(r.Output)
+}
+
+// Evaluator evaluates checks against the chain.
+type Evaluator struct {
+	chain ChainReader
+	// pollInterval is how often the head is polled for new blocks.
+	pollInterval time.Duration
+}
+
+func NewEvaluator(chain ChainReader, pollInterval time.Duration) *Evaluator {
+	return &Evaluator{chain: chain, pollInterval: pollInterval}
+}
+
+// Evaluate evaluates the check once, at block. script is only used by
+// KindScript checks.
+func (e *Evaluator) Evaluate(ctx context.Context, spec *Spec, script ScriptFunc, block uint64) (Result, error) {
+	result := Result{Block: block}
+	switch spec.Kind {
+	case KindCall:
+		msg := ethereum.CallMsg{To: &spec.Target, Data: spec.Data}
+		output, err := e.chain.CallContract(ctx, msg, new(big.Int).SetUint64(block))
+		if err != nil {
+			return result, fmt.Errorf("check call to %s failed: %w", spec.Target.Hex(), err)
+		}
+		if len(output) < 32 {
+			return result, fmt.Errorf("check call to %s returned %d bytes, want at least a word", spec.Target.Hex(), len(output))
+		}
+		result.Output = output
+		result.Holds = new(big.Int).SetBytes(output[:32]).Sign() != 0
+	case KindScript:
+		output, err := script(ctx, block)
+		if err != nil {
+			return result, fmt.Errorf("check script failed: %w", err)
+		}
+		if err := json.Unmarshal(output, &result.Holds); err != nil {
+			return result, fmt.Errorf("check script returned %s, want true or false", output)
+		}
+		result.Output = output
+	default:
+		return result, fmt.Errorf("unknown check kind %q", spec.Kind)
+	}
+	return result, nil
+}
+
+// Wait evaluates the check at the blocks from start on until it holds, and
+// returns that evaluation. The check is evaluated at every block, or with
+// spec.Every at the first block that is at least spec.Every later than the
+// last block it was evaluated at, and until the block that is more than
+// window later than start, after which Wait returns ErrWindowOver. A window
+// of zero does not end. The blocks evaluated only depend on start and the
+// chain, so operators that wait for the same task, at any time, find the
+// check holding at the same block. A failing evaluation counts as the check
+// not holding. If ctx ends first, Wait returns the last evaluation with ctx's
+// error, and the error of the last evaluation if it failed.
+func (e *Evaluator) Wait(ctx context.Context, spec *Spec, script ScriptFunc, start uint64, window time.Duration) (Result, error) {
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+
+	var last Result
+	var lastErr error
+	var startTime, evaluatedTime uint64
+	for block := start; ; {
+		// blocks that are mined already are evaluated without waiting
+		header, err := e.chain.HeaderByNumber(ctx, new(big.Int).SetUint64(block))
+		if err == nil {
+			if block == start {
+				startTime = header.Time
+			}
+			if window > 0 && header.Time-startTime > uint64(window/time.Second) {
+				if lastErr != nil {
+					return last, fmt.Errorf("%w (last check: %v)", ErrWindowOver, lastErr)
+				}
+				return last, ErrWindowOver
+			}
+			if block == start || header.Time-evaluatedTime >= uint64(spec.Every/time.Second) {
+				evaluatedTime = header.Time
+				last, lastErr = e.Evaluate(ctx, spec, script, block)
+				if lastErr == nil && last.Holds {
+					return last, nil
+				}
+			}
+			block++
+			continue
+		}
+
+		// the block is not mined yet, or the node cannot be reached
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return last, fmt.Errorf("%w (last check: %v)", ctx.Err(), lastErr)
+			}
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
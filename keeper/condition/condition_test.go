@@ -0,0 +1,145 @@
+package condition
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestParse(t *testing.T) {
+	spec, err := Parse("Keeps the vault topped up\ncheck: call 0x00000000000000000000000000000000000000aa checkUpkeep()\ncheck_every: 30s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Kind != KindCall || spec.Target != common.HexToAddress("0xaa") || spec.Every != 30*time.Second {
+		t.Errorf("Parse = %+v", spec)
+	}
+	if len(spec.Data) != 4 {
+		t.Errorf("Data = %x, want a selector", spec.Data)
+	}
+
+	spec, err = Parse("check: script")
+	if err != nil || spec.Kind != KindScript || spec.Every != 0 {
+		t.Errorf("Parse(script) = %+v, %v", spec, err)
+	}
+	if spec, err := Parse("no check here"); spec != nil || err != nil {
+		t.Errorf("Parse without a check = %+v, %v", spec, err)
+	}
+	for _, description := range []string{
+		"check: call nope checkUpkeep()",
+		"check: call 0x00000000000000000000000000000000000000aa checkUpkeep(uint256)",
+		"check: poll",
+		"check: script\ncheck_every: soon",
+		"check_every: 1m",
+	} {
+		if _, err := Parse(description); err == nil {
+			t.Errorf("Parse(%q) returned no error", description)
+		}
+	}
+}
+
+// fakeChain mines a block every 12 seconds, one per HeaderByNumber call for
+// a block that is not mined yet, and its check holds from block holdsAt on.
+type fakeChain struct {
+	mu      sync.Mutex
+	head    uint64
+	holdsAt uint64
+	calls   []uint64
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if number.Uint64() > c.head {
+		c.head++
+		return nil, ethereum.NotFound
+	}
+	return &types.Header{Number: number, Time: 12 * number.Uint64()}, nil
+}
+
+func (c *fakeChain) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, blockNumber.Uint64())
+	output := make([]byte, 32)
+	if blockNumber.Uint64() >= c.holdsAt {
+		output[31] = 1
+	}
+	return output, nil
+}
+
+func TestWaitReturnsTheBlockTheCheckHoldsAt(t *testing.T) {
+	chain := &fakeChain{head: 1, holdsAt: 3}
+	spec := &Spec{Kind: KindCall, Target: common.HexToAddress("0xaa")}
+
+	result, err := NewEvaluator(chain, time.Millisecond).Wait(context.Background(), spec, nil, 1, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Holds || result.Block != 3 {
+		t.Errorf("Wait = %+v, want to hold at block 3", result)
+	}
+	if len(chain.calls) != 3 {
+		t.Errorf("check evaluated at blocks %v, want 1 to 3", chain.calls)
+	}
+}
+
+func TestWaitEvaluatesTheSameBlocksWhenStartedLater(t *testing.T) {
+	spec := &Spec{Kind: KindCall, Target: common.HexToAddress("0xaa"), Every: 30 * time.Second}
+	var evaluated [][]uint64
+	for _, head := range []uint64{10, 20} {
+		chain := &fakeChain{head: head, holdsAt: 16}
+		result, err := NewEvaluator(chain, time.Millisecond).Wait(context.Background(), spec, nil, 10, 2*time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Block != 16 {
+			t.Errorf("Wait with the head at %d = %+v, want to hold at block 16", head, result)
+		}
+		evaluated = append(evaluated, chain.calls)
+	}
+	if want := []uint64{10, 13, 16}; !slices.Equal(evaluated[0], want) || !slices.Equal(evaluated[1], want) {
+		t.Errorf("check evaluated at blocks %v, want %v both times", evaluated, want)
+	}
+}
+
+func TestWaitGivesUpAfterTheWindow(t *testing.T) {
+	chain := &fakeChain{head: 1, holdsAt: 100}
+	spec := &Spec{Kind: KindCall, Target: common.HexToAddress("0xaa")}
+
+	result, err := NewEvaluator(chain, time.Millisecond).Wait(context.Background(), spec, nil, 1, time.Minute)
+	if !errors.Is(err, ErrWindowOver) {
+		t.Fatalf("Wait error = %v, want ErrWindowOver", err)
+	}
+	if result.Holds || result.Block != 6 {
+		t.Errorf("Wait = %+v, want the evaluation at block 6, the last of the window", result)
+	}
+}
+
+func TestWaitGivesUpWhenTheContextEnds(t *testing.T) {
+	chain := &fakeChain{head: 1, holdsAt: 1}
+	script := func(ctx context.Context, block uint64) ([]byte, error) {
+		return nil, errors.New("boom")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	result, err := NewEvaluator(chain, time.Millisecond).Wait(ctx, &Spec{Kind: KindScript}, script, 1, 0)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait error = %v, want the deadline", err)
+	}
+	if result.Holds || result.Block == 0 {
+		t.Errorf("Wait = %+v, want the last evaluation that did not hold", result)
+	}
+}
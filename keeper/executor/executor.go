@@ -0,0 +1,113 @@
+// Package executor runs keeper job code inside sandboxed runtimes and returns
+// the structured result that the keeper signs and sends to the aggregator.
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTimeLimit      = errors.New("job exceeded its execution time limit")
+	ErrMemoryLimit    = errors.New("job exceeded its memory limit")
+	ErrOutputTooLarge = errors.New("job result exceeds the maximum output size")
+	ErrNoEntrypoint   = errors.New("job code does not define the entrypoint")
+	ErrNoResult       = errors.New("job did not return a result")
+)
+
+// Limits bounds the resources a single job execution may consume.
+type Limits struct {
+	// MaxExecutionTime is the wall-clock time a job may run for before it is
+	// interrupted.
+	MaxExecutionTime time.Duration
+	// MaxMemoryBytes is the amount of heap a job may allocate while it runs.
+	// JS jobs with a memory limit run in a child process, see RunJSChild.
+	MaxMemoryBytes uint64
+	// MaxOutputBytes is the maximum size of the encoded result.
+	MaxOutputBytes int
+}
+
+var DefaultLimits = Limits{
+	MaxExecutionTime: 10 * time.Second,
+	MaxMemoryBytes:   64 << 20,
+	MaxOutputBytes:   64 << 10,
+}
+
+// Entrypoints a job's code may define.
+const (
+	// EntrypointRun executes the job.
+	EntrypointRun = "run"
+	// EntrypointCheck evaluates the job's condition, for jobs that declare
+	// "check: script". It returns true or false.
+	EntrypointCheck = "check"
+)
+
+// Input is what the keeper passes to the job's entrypoint.
+type Input struct {
+	JobID  uint32                 `json:"jobID"`
+	TaskID uint32                 `json:"taskID"`
+	Args   map[string]interface{} `json:"args,omitempty"`
+	// BlockNumber is the block the job's condition held at, and the block the
+	// chain host functions of WASM jobs read from. Zero means the latest block.
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+	// BlockTimestamp is the timestamp of the block the task runs at, which
+	// JS jobs see as the current time. Zero is the Unix epoch.
+	BlockTimestamp uint64 `json:"blockTimestamp,omitempty"`
+	// Entrypoint is the function to call, EntrypointRun if empty.
+	Entrypoint string `json:"-"`
+}
+
+func (i Input) entrypoint() string {
+	if i.Entrypoint == "" {
+		return EntrypointRun
+	}
+	return i.Entrypoint
+}
+
+// Result is the outcome of a successful job execution.
+type Result struct {
+	// Output is the data the keeper signs: the JSON encoding of the value
+	// returned by a JS job, or the bytes a WASM job passed to set_result.
+	Output   []byte
+	Logs     []string
+	Duration time.Duration
+}
+
+// Executor runs job code and returns its result.
+type Executor interface {
+	Execute(ctx context.Context, code []byte, input Input) (*Result, error)
+}
+
+// Backend identifies the runtime a job is executed in.
+type Backend string
+
+const (
+	BackendJS   Backend = "js"
+	BackendWasm Backend = "wasm"
+)
+
+// BackendForJobType maps a job's JobType to the runtime that executes it. Job
+// types starting with "wasm" run as WebAssembly; everything else is treated
+// as JavaScript, which is what jobs were before WASM support existed.
+func BackendForJobType(jobType string) Backend {
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(jobType)), string(BackendWasm)) {
+		return BackendWasm
+	}
+	return BackendJS
+}
+
+// Registry holds one executor per backend.
+type Registry map[Backend]Executor
+
+// ForJobType returns the executor for the job's backend.
+func (r Registry) ForJobType(jobType string) (Executor, error) {
+	backend := BackendForJobType(jobType)
+	e, ok := r[backend]
+	if !ok {
+		return nil, fmt.Errorf("no executor configured for %s jobs", backend)
+	}
+	return e, nil
+}
@@ -0,0 +1,203 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime/metrics"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+const (
+	jsMaxCallStackSize   = 1024
+	jsMaxLogLines        = 256
+	memoryPollInterval   = 5 * time.Millisecond
+	heapObjectsMetricKey = "/memory/classes/heap/objects:bytes"
+)
+
+// JSExecutor runs job scripts in an embedded JavaScript engine.
+//
+// A job script must define a global function run(input) and return the job
+// result from it, and a global function check(input) if its job has a
+// scripted condition. The engine exposes no filesystem, network or module loader;
+// the only globals besides the ECMAScript builtins are console.log and
+// console.error, which are captured into Result.Logs. Math.random is seeded from
+// the task and Date returns Input.BlockTimestamp, so that every operator
+// computes the same result. Jobs under a memory limit run in a child process
+// of their own, see RunJSChild.
+type JSExecutor struct {
+	limits Limits
+	// childPath is the program started to run a job under a memory limit
+	childPath string
+}
+
+var _ Executor = (*JSExecutor)(nil)
+
+func NewJSExecutor(limits Limits) *JSExecutor {
+	// without the path, jobs under a memory limit fail to start
+	childPath, _ := os.Executable()
+	return &JSExecutor{limits: limits, childPath: childPath}
+}
+
+func (e *JSExecutor) Execute(ctx context.Context, code []byte, input Input) (*Result, error) {
+	if e.limits.MaxMemoryBytes > 0 {
+		return e.executeInChild(ctx, code, input)
+	}
+	return e.execute(ctx, code, input)
+}
+
+// execute runs the job in this process. Its memory limit is checked against
+// the heap of the whole process, which is the job's own in a child process.
+func (e *JSExecutor) execute(ctx context.Context, code []byte, input Input) (*Result, error) {
+	start := time.Now()
+
+	vm := goja.New()
+	vm.SetFieldNameMapper(goja.TagFieldNameMapper("json", true))
+	vm.SetMaxCallStackSize(jsMaxCallStackSize)
+	rng := rand.New(rand.NewSource(int64(input.JobID)<<32 | int64(input.TaskID)))
+	vm.SetRandSource(rng.Float64)
+	now := time.Unix(int64(input.BlockTimestamp), 0)
+	vm.SetTimeSource(func() time.Time { return now })
+
+	var logs []string
+	if err := installConsole(vm, &logs); err != nil {
+		return nil, err
+	}
+
+	if e.limits.MaxExecutionTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.limits.MaxExecutionTime)
+		defer cancel()
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go e.watch(ctx, vm, done)
+
+	value, err := runEntrypoint(vm, code, input)
+	if err != nil {
+		return nil, interruptCause(err)
+	}
+
+	output, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("job result is not JSON serializable: %w", err)
+	}
+	if e.limits.MaxOutputBytes > 0 && len(output) > e.limits.MaxOutputBytes {
+		return nil, ErrOutputTooLarge
+	}
+
+	return &Result{
+		Output:   output,
+		Logs:     logs,
+		Duration: time.Since(start),
+	}, nil
+}
+
+func runEntrypoint(vm *goja.Runtime, code []byte, input Input) (interface{}, error) {
+	if _, err := vm.RunString(string(code)); err != nil {
+		return nil, err
+	}
+	run, ok := goja.AssertFunction(vm.Get(input.entrypoint()))
+	if !ok {
+		return nil, ErrNoEntrypoint
+	}
+	value, err := run(goja.Undefined(), vm.ToValue(input))
+	if err != nil {
+		return nil, err
+	}
+
+	// There is no event loop in the sandbox, so an async run() is only
+	// accepted if its promise has already settled.
+	if promise, ok := value.Export().(*goja.Promise); ok {
+		switch promise.State() {
+		case goja.PromiseStateFulfilled:
+			value = promise.Result()
+		case goja.PromiseStateRejected:
+			return nil, fmt.Errorf("job promise rejected: %v", promise.Result())
+		default:
+			return nil, errors.New("job returned a promise that never settled")
+		}
+	}
+
+	if goja.IsUndefined(value) || goja.IsNull(value) {
+		return nil, ErrNoResult
+	}
+	return value.Export(), nil
+}
+
+// watch interrupts the VM when the context ends or the job allocates more
+// heap than it is allowed to. The heap is measured process wide, so the
+// limit only holds for a job that runs in a process of its own.
+func (e *JSExecutor) watch(ctx context.Context, vm *goja.Runtime, done <-chan struct{}) {
+	var ticker *time.Ticker
+	var tick <-chan time.Time
+	sample := []metrics.Sample{{Name: heapObjectsMetricKey}}
+	var baseline uint64
+	if e.limits.MaxMemoryBytes > 0 {
+		metrics.Read(sample)
+		baseline = sample[0].Value.Uint64()
+		ticker = time.NewTicker(memoryPollInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				vm.Interrupt(ErrTimeLimit)
+			} else {
+				vm.Interrupt(ctx.Err())
+			}
+			return
+		case <-tick:
+			metrics.Read(sample)
+			if heap := sample[0].Value.Uint64(); heap > baseline && heap-baseline > e.limits.MaxMemoryBytes {
+				vm.Interrupt(ErrMemoryLimit)
+				return
+			}
+		}
+	}
+}
+
+func installConsole(vm *goja.Runtime, logs *[]string) error {
+	logFn := func(call goja.FunctionCall) goja.Value {
+		if len(*logs) >= jsMaxLogLines {
+			return goja.Undefined()
+		}
+		parts := make([]string, len(call.Arguments))
+		for i, arg := range call.Arguments {
+			parts[i] = arg.String()
+		}
+		*logs = append(*logs, strings.Join(parts, " "))
+		return goja.Undefined()
+	}
+	console := vm.NewObject()
+	if err := console.Set("log", logFn); err != nil {
+		return err
+	}
+	if err := console.Set("error", logFn); err != nil {
+		return err
+	}
+	return vm.Set("console", console)
+}
+
+// interruptCause unwraps the error a job was interrupted with, so callers can
+// match it against ErrTimeLimit and ErrMemoryLimit.
+func interruptCause(err error) error {
+	var interrupted *goja.InterruptedError
+	if errors.As(err, &interrupted) {
+		if cause, ok := interrupted.Value().(error); ok {
+			return cause
+		}
+	}
+	return err
+}
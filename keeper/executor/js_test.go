@@ -0,0 +1,125 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	// the test binary is the child process of the jobs under a memory limit
+	RunJSChild()
+	os.Exit(m.Run())
+}
+
+func TestJSExecutorReturnsStructuredResult(t *testing.T) {
+	e := NewJSExecutor(DefaultLimits)
+	code := `
+		function run(input) {
+			console.log("job", input.jobID);
+			return { jobID: input.jobID, doubled: input.args.value * 2 };
+		}`
+
+	result, err := e.Execute(context.Background(), []byte(code), Input{JobID: 7, Args: map[string]interface{}{"value": 21}})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got, want := string(result.Output), `{"doubled":42,"jobID":7}`; got != want {
+		t.Errorf("Output = %s, want %s", got, want)
+	}
+	if len(result.Logs) != 1 || result.Logs[0] != "job 7" {
+		t.Errorf("Logs = %v, want [job 7]", result.Logs)
+	}
+}
+
+func TestJSExecutorIsDeterministic(t *testing.T) {
+	e := NewJSExecutor(DefaultLimits)
+	code := `function run() { return { now: Date.now(), random: Math.random() }; }`
+	input := Input{JobID: 7, TaskID: 3, BlockTimestamp: 1700000000}
+
+	first, err := e.Execute(context.Background(), []byte(code), input)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	second, err := e.Execute(context.Background(), []byte(code), input)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if string(first.Output) != string(second.Output) {
+		t.Errorf("outputs differ: %s and %s", first.Output, second.Output)
+	}
+	var result struct{ Now int64 }
+	if err := json.Unmarshal(first.Output, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Now != 1700000000000 {
+		t.Errorf("Date.now() = %d, want the block timestamp 1700000000000", result.Now)
+	}
+}
+
+func TestJSExecutorIsSandboxed(t *testing.T) {
+	e := NewJSExecutor(DefaultLimits)
+	for _, code := range []string{
+		`function run() { return require("fs").readFileSync("/etc/passwd"); }`,
+		`function run() { return fetch("http://example.com"); }`,
+	} {
+		if _, err := e.Execute(context.Background(), []byte(code), Input{}); err == nil {
+			t.Errorf("expected %q to fail in the sandbox", code)
+		}
+	}
+}
+
+func TestJSExecutorEnforcesLimits(t *testing.T) {
+	e := NewJSExecutor(Limits{MaxExecutionTime: 100 * time.Millisecond, MaxMemoryBytes: 16 << 20})
+	_, err := e.Execute(context.Background(), []byte(`function run() { for (;;) {} }`), Input{})
+	if !errors.Is(err, ErrTimeLimit) {
+		t.Errorf("infinite loop: got err %v, want %v", err, ErrTimeLimit)
+	}
+
+	e = NewJSExecutor(Limits{MaxExecutionTime: time.Minute, MaxMemoryBytes: 16 << 20})
+	_, err = e.Execute(context.Background(), []byte(`function run() { var a = []; for (;;) { a.push(new Array(1024).fill(1)); } }`), Input{})
+	if !errors.Is(err, ErrMemoryLimit) {
+		t.Errorf("unbounded allocation: got err %v, want %v", err, ErrMemoryLimit)
+	}
+}
+
+func TestJSExecutorRequiresEntrypointAndResult(t *testing.T) {
+	e := NewJSExecutor(DefaultLimits)
+
+	if _, err := e.Execute(context.Background(), []byte(`var x = 1;`), Input{}); !errors.Is(err, ErrNoEntrypoint) {
+		t.Errorf("got err %v, want %v", err, ErrNoEntrypoint)
+	}
+	if _, err := e.Execute(context.Background(), []byte(`function run() {}`), Input{}); !errors.Is(err, ErrNoResult) {
+		t.Errorf("got err %v, want %v", err, ErrNoResult)
+	}
+}
+
+func TestJSMemoryLimitIsPerJob(t *testing.T) {
+	e := NewJSExecutor(Limits{MaxExecutionTime: time.Minute, MaxMemoryBytes: 16 << 20})
+	var wg sync.WaitGroup
+	var hogErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, hogErr = e.Execute(context.Background(), []byte(`function run() { var a = []; for (;;) { a.push(new Array(1024).fill(1)); } }`), Input{})
+	}()
+	// jobs under a memory limit run side by side, and are not charged for each other's heap
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := e.Execute(context.Background(), []byte(`function run(input) { return input.jobID; }`), Input{JobID: 7})
+			if err != nil || string(result.Output) != "7" {
+				t.Errorf("small job next to a memory hog: got %v, %v", result, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if !errors.Is(hogErr, ErrMemoryLimit) {
+		t.Errorf("unbounded allocation: got err %v, want %v", hogErr, ErrMemoryLimit)
+	}
+}
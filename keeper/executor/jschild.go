@@ -0,0 +1,123 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JS jobs under a memory limit run in a child process of their own: the engine
+// does not account memory per VM, and the heap of a process that runs a single
+// job is the job's own. The child is the running program started again with
+// jsChildEnv set, which makes RunJSChild run the job it is sent.
+const (
+	jsChildEnv = "KEEPER_JS_JOB_CHILD"
+	// jsChildGrace is how much longer than the job's time limit the child may
+	// take, to start and to report that the job ran out of time.
+	jsChildGrace = 5 * time.Second
+)
+
+// jsChildErrors are the errors a child reports as such, so that callers can
+// match them.
+var jsChildErrors = []error{ErrTimeLimit, ErrMemoryLimit, ErrOutputTooLarge, ErrNoEntrypoint, ErrNoResult}
+
+type jsChildRequest struct {
+	Code       []byte `json:"code"`
+	Input      Input  `json:"input"`
+	Entrypoint string `json:"entrypoint,omitempty"`
+	Limits     Limits `json:"limits"`
+}
+
+type jsChildResponse struct {
+	Result *Result `json:"result,omitempty"`
+	Error  string  `json:"error,omitempty"`
+	// Sentinel is the text of the error of jsChildErrors the job failed with.
+	Sentinel string `json:"sentinel,omitempty"`
+}
+
+// RunJSChild runs the JS job the process was started for and exits, if it was
+// started by a JSExecutor to run one. Programs that run JS jobs under a memory
+// limit call it first thing in main.
+func RunJSChild() {
+	if os.Getenv(jsChildEnv) == "" {
+		return
+	}
+	if err := serveJSChild(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	os.Exit(0)
+}
+
+func serveJSChild(r io.Reader, w io.Writer) error {
+	var request jsChildRequest
+	if err := json.NewDecoder(r).Decode(&request); err != nil {
+		return fmt.Errorf("reading the job: %w", err)
+	}
+	request.Input.Entrypoint = request.Entrypoint
+	e := &JSExecutor{limits: request.Limits}
+	result, err := e.execute(context.Background(), request.Code, request.Input)
+	response := jsChildResponse{Result: result}
+	if err != nil {
+		response.Error = err.Error()
+		for _, sentinel := range jsChildErrors {
+			if errors.Is(err, sentinel) {
+				response.Sentinel = sentinel.Error()
+			}
+		}
+	}
+	return json.NewEncoder(w).Encode(response)
+}
+
+// executeInChild runs the job in a child process, see RunJSChild.
+func (e *JSExecutor) executeInChild(ctx context.Context, code []byte, input Input) (*Result, error) {
+	if e.limits.MaxExecutionTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.limits.MaxExecutionTime+jsChildGrace)
+		defer cancel()
+	}
+	request, err := json.Marshal(jsChildRequest{Code: code, Input: input, Entrypoint: input.Entrypoint, Limits: e.limits})
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.childPath)
+	// the collector keeps the heap close to what the job holds, rather than
+	// letting garbage count against its limit
+	cmd.Env = append(os.Environ(), jsChildEnv+"=1", "GOMEMLIMIT="+strconv.FormatUint(e.limits.MaxMemoryBytes, 10))
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, ErrTimeLimit
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("job process failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var response jsChildResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("job process returned %q: %w", stdout.String(), err)
+	}
+	for _, sentinel := range jsChildErrors {
+		if response.Sentinel == sentinel.Error() {
+			return nil, sentinel
+		}
+	}
+	if response.Error != "" {
+		return nil, errors.New(response.Error)
+	}
+	return response.Result, nil
+}
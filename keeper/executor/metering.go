@@ -0,0 +1,479 @@
+package executor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// WASM jobs are metered by rewriting their module before it is compiled. The
+// module gets a mutable i64 global, exported as wasmFuelGlobal, that holds the
+// fuel left. Every function body, and the body of every loop, starts by
+// subtracting the number of instructions it holds outside of its nested loops,
+// and traps once the fuel runs out. Every instruction that runs is charged for
+// by the entry of its function or the header of its loop before it runs, so a
+// job can run at most as many instructions as it has fuel, whether it makes
+// calls or not, and it runs out of fuel at the same point on every operator.
+const wasmFuelGlobal = "keeper_fuel"
+
+// section ids of the binary format
+const (
+	wasmSectionCustom    = 0
+	wasmSectionImport    = 2
+	wasmSectionGlobal    = 6
+	wasmSectionExport    = 7
+	wasmSectionCode      = 10
+	wasmSectionDataCount = 12
+)
+
+// wasmSectionOrder is the position of the non-custom sections in a module.
+var wasmSectionOrder = map[byte]int{1: 1, 2: 2, 3: 3, 4: 4, 5: 5, 6: 6, 7: 7, 8: 8, 9: 9, 12: 10, 10: 11, 11: 12}
+
+var errUnsupportedInstruction = errors.New("unsupported instruction")
+
+type wasmSection struct {
+	id   byte
+	body []byte
+}
+
+// meterModule returns the module rewritten to run on fuel, starting with fuel.
+func meterModule(code []byte, fuel uint64) ([]byte, error) {
+	if len(code) < 8 || !bytes.Equal(code[:4], []byte("\x00asm")) {
+		return nil, errors.New("not a wasm module")
+	}
+	var sections []wasmSection
+	for r := (&wasmReader{b: code, pos: 8}); r.pos < len(r.b); {
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		body, err := r.bytes()
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, wasmSection{id: id, body: body})
+	}
+
+	importedGlobals, definedGlobals := uint32(0), uint32(0)
+	for _, section := range sections {
+		var err error
+		switch section.id {
+		case wasmSectionImport:
+			importedGlobals, err = countImportedGlobals(section.body)
+		case wasmSectionGlobal:
+			definedGlobals, err = (&wasmReader{b: section.body}).u32()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	fuelGlobal := importedGlobals + definedGlobals
+
+	global := append([]byte{0x7e, 0x01, 0x42}, appendSLEB(nil, int64(fuel))...)
+	global = append(global, 0x0b)
+	export := append(appendName(nil, wasmFuelGlobal), 0x03)
+	export = appendULEB(export, uint64(fuelGlobal))
+
+	var err error
+	if sections, err = appendToVecSection(sections, wasmSectionGlobal, global); err != nil {
+		return nil, err
+	}
+	if sections, err = appendToVecSection(sections, wasmSectionExport, export); err != nil {
+		return nil, err
+	}
+	for i, section := range sections {
+		if section.id == wasmSectionCode {
+			if sections[i].body, err = meterCode(section.body, fuelGlobal); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	out := append([]byte(nil), code[:8]...)
+	for _, section := range sections {
+		out = append(out, section.id)
+		out = appendULEB(out, uint64(len(section.body)))
+		out = append(out, section.body...)
+	}
+	return out, nil
+}
+
+func countImportedGlobals(body []byte) (uint32, error) {
+	r := &wasmReader{b: body}
+	n, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+	var globals uint32
+	for i := uint32(0); i < n; i++ {
+		if _, err := r.bytes(); err != nil { // module
+			return 0, err
+		}
+		if _, err := r.bytes(); err != nil { // name
+			return 0, err
+		}
+		kind, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		switch kind {
+		case 0x00: // function
+			_, err = r.u32()
+		case 0x01: // table
+			if _, err = r.byte(); err == nil {
+				err = r.limits()
+			}
+		case 0x02: // memory
+			err = r.limits()
+		case 0x03: // global
+			_, err = r.read(2)
+			globals++
+		default:
+			err = fmt.Errorf("unknown import kind %#x", kind)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return globals, nil
+}
+
+// appendToVecSection adds an entry to the vector that makes up the section
+// with the id, creating the section where it belongs if the module has none.
+func appendToVecSection(sections []wasmSection, id byte, entry []byte) ([]wasmSection, error) {
+	for i, section := range sections {
+		if section.id != id {
+			continue
+		}
+		r := &wasmReader{b: section.body}
+		n, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		if id == wasmSectionExport && exportsName(section.body, wasmFuelGlobal) {
+			return nil, fmt.Errorf("module exports %q, which the keeper reserves", wasmFuelGlobal)
+		}
+		body := appendULEB(nil, uint64(n)+1)
+		body = append(body, section.body[r.pos:]...)
+		sections[i].body = append(body, entry...)
+		return sections, nil
+	}
+	at := len(sections)
+	for i, section := range sections {
+		if section.id != wasmSectionCustom && wasmSectionOrder[section.id] > wasmSectionOrder[id] {
+			at = i
+			break
+		}
+	}
+	section := wasmSection{id: id, body: append([]byte{0x01}, entry...)}
+	return append(sections[:at], append([]wasmSection{section}, sections[at:]...)...), nil
+}
+
+func exportsName(body []byte, name string) bool {
+	r := &wasmReader{b: body}
+	n, err := r.u32()
+	for i := uint32(0); err == nil && i < n; i++ {
+		var exported []byte
+		if exported, err = r.bytes(); err == nil && string(exported) == name {
+			return true
+		}
+		if err == nil {
+			_, err = r.read(1)
+		}
+		if err == nil {
+			_, err = r.u32()
+		}
+	}
+	return false
+}
+
+// meterCode adds the fuel charges to the function bodies of a code section.
+func meterCode(body []byte, fuelGlobal uint32) ([]byte, error) {
+	r := &wasmReader{b: body}
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	out := appendULEB(nil, uint64(n))
+	for i := uint32(0); i < n; i++ {
+		function, err := r.bytes()
+		if err != nil {
+			return nil, err
+		}
+		metered, err := meterFunction(function, fuelGlobal)
+		if err != nil {
+			return nil, fmt.Errorf("function %d: %w", i, err)
+		}
+		out = appendULEB(out, uint64(len(metered)))
+		out = append(out, metered...)
+	}
+	return out, nil
+}
+
+// meterFunction charges the instructions of a function body at its entry and
+// at the header of each of its loops.
+func meterFunction(function []byte, fuelGlobal uint32) ([]byte, error) {
+	r := &wasmReader{b: function}
+	locals, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < locals; i++ {
+		if _, err := r.u32(); err != nil {
+			return nil, err
+		}
+		if _, err := r.byte(); err != nil {
+			return nil, err
+		}
+	}
+	start := r.pos
+
+	// charges[i] is charged at offset at[i]; charge 0 is the function's
+	type control struct {
+		loop   bool
+		charge int
+	}
+	at := []int{start}
+	charges := []uint64{0}
+	stack := []control{{charge: 0}}
+	for len(stack) > 0 {
+		opcode, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		charges[stack[len(stack)-1].charge]++
+		switch opcode {
+		case 0x02, 0x03, 0x04: // block, loop, if
+			if err := r.blockType(); err != nil {
+				return nil, err
+			}
+			frame := control{charge: stack[len(stack)-1].charge}
+			if opcode == 0x03 {
+				frame = control{loop: true, charge: len(charges)}
+				at = append(at, r.pos)
+				charges = append(charges, 0)
+			}
+			stack = append(stack, frame)
+		case 0x0b: // end
+			stack = stack[:len(stack)-1]
+		default:
+			if err := r.immediates(opcode); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if r.pos != len(function) {
+		return nil, errors.New("code after the end of the function")
+	}
+
+	out := append([]byte(nil), function[:start]...)
+	from := start
+	for i, offset := range at {
+		out = append(out, function[from:offset]...)
+		out = appendCharge(out, fuelGlobal, charges[i])
+		from = offset
+	}
+	return append(out, function[from:]...), nil
+}
+
+// appendCharge appends the instructions that take cost from the fuel global,
+// and trap if it is used up.
+func appendCharge(out []byte, fuelGlobal uint32, cost uint64) []byte {
+	out = append(out, 0x23) // global.get
+	out = appendULEB(out, uint64(fuelGlobal))
+	out = append(out, 0x42) // i64.const
+	out = appendSLEB(out, int64(cost))
+	out = append(out, 0x7d, 0x24) // i64.sub, global.set
+	out = appendULEB(out, uint64(fuelGlobal))
+	out = append(out, 0x23) // global.get
+	out = appendULEB(out, uint64(fuelGlobal))
+	// i64.const 0, i64.lt_s, if, unreachable, end
+	return append(out, 0x42, 0x00, 0x53, 0x04, 0x40, 0x00, 0x0b)
+}
+
+type wasmReader struct {
+	b   []byte
+	pos int
+}
+
+var errTruncated = errors.New("truncated wasm module")
+
+func (r *wasmReader) byte() (byte, error) {
+	if r.pos >= len(r.b) {
+		return 0, errTruncated
+	}
+	r.pos++
+	return r.b[r.pos-1], nil
+}
+
+func (r *wasmReader) read(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.b) {
+		return nil, errTruncated
+	}
+	r.pos += n
+	return r.b[r.pos-n : r.pos], nil
+}
+
+// leb skips a LEB128 number of at most maxBytes bytes and returns its value,
+// which is only meaningful for unsigned numbers.
+func (r *wasmReader) leb(maxBytes int) (uint64, error) {
+	var value uint64
+	for i := 0; i < maxBytes; i++ {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		value |= uint64(b&0x7f) << (7 * i)
+		if b < 0x80 {
+			return value, nil
+		}
+	}
+	return 0, errors.New("malformed LEB128 number")
+}
+
+func (r *wasmReader) u32() (uint32, error) {
+	value, err := r.leb(5)
+	return uint32(value), err
+}
+
+func (r *wasmReader) bytes() ([]byte, error) {
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	return r.read(int(n))
+}
+
+func (r *wasmReader) limits() error {
+	flags, err := r.byte()
+	if err != nil {
+		return err
+	}
+	if _, err := r.u32(); err != nil {
+		return err
+	}
+	if flags&0x01 != 0 {
+		_, err = r.u32()
+	}
+	return err
+}
+
+func (r *wasmReader) blockType() error {
+	b, err := r.byte()
+	if err != nil {
+		return err
+	}
+	switch b {
+	case 0x40, 0x7f, 0x7e, 0x7d, 0x7c, 0x7b, 0x70, 0x6f:
+		return nil
+	}
+	// a type index, a signed 33 bit number
+	r.pos--
+	_, err = r.leb(5)
+	return err
+}
+
+// immediates skips the immediates of an instruction other than block, loop,
+// if and end.
+func (r *wasmReader) immediates(opcode byte) error {
+	var err error
+	switch {
+	case opcode == 0x0c || opcode == 0x0d || opcode == 0x10 || opcode == 0xd2 ||
+		(opcode >= 0x20 && opcode <= 0x26): // br, br_if, call, ref.func, locals, globals, tables
+		_, err = r.u32()
+	case opcode == 0x0e: // br_table
+		var n uint32
+		if n, err = r.u32(); err == nil {
+			for i := uint32(0); i <= n && err == nil; i++ {
+				_, err = r.u32()
+			}
+		}
+	case opcode == 0x11: // call_indirect
+		if _, err = r.u32(); err == nil {
+			_, err = r.u32()
+		}
+	case opcode == 0x1c: // select with types
+		var n uint32
+		if n, err = r.u32(); err == nil {
+			_, err = r.read(int(n))
+		}
+	case opcode >= 0x28 && opcode <= 0x3e: // loads and stores
+		if _, err = r.u32(); err == nil {
+			_, err = r.u32()
+		}
+	case opcode == 0x3f || opcode == 0x40 || opcode == 0xd0: // memory.size, memory.grow, ref.null
+		_, err = r.read(1)
+	case opcode == 0x41:
+		_, err = r.leb(5)
+	case opcode == 0x42:
+		_, err = r.leb(10)
+	case opcode == 0x43:
+		_, err = r.read(4)
+	case opcode == 0x44:
+		_, err = r.read(8)
+	case opcode == 0xfc:
+		err = r.miscImmediates()
+	case opcode <= 0x01 || opcode == 0x05 || opcode == 0x0f || opcode == 0x1a || opcode == 0x1b ||
+		(opcode >= 0x45 && opcode <= 0xc4) || opcode == 0xd1:
+		// no immediates
+	default:
+		err = fmt.Errorf("%w %#x", errUnsupportedInstruction, opcode)
+	}
+	return err
+}
+
+// miscImmediates skips the immediates of an instruction with the 0xfc prefix.
+func (r *wasmReader) miscImmediates() error {
+	op, err := r.u32()
+	if err != nil {
+		return err
+	}
+	switch {
+	case op <= 7: // saturating truncations
+		return nil
+	case op == 8: // memory.init
+		if _, err = r.u32(); err == nil {
+			_, err = r.read(1)
+		}
+	case op == 10: // memory.copy
+		_, err = r.read(2)
+	case op == 11: // memory.fill
+		_, err = r.read(1)
+	case op == 12 || op == 14: // table.init, table.copy
+		if _, err = r.u32(); err == nil {
+			_, err = r.u32()
+		}
+	case op == 9 || op == 13 || (op >= 15 && op <= 17): // data.drop, elem.drop, table.grow, size, fill
+		_, err = r.u32()
+	default:
+		err = fmt.Errorf("%w 0xfc %d", errUnsupportedInstruction, op)
+	}
+	return err
+}
+
+func appendULEB(out []byte, value uint64) []byte {
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if value == 0 {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func appendSLEB(out []byte, value int64) []byte {
+	for {
+		b := byte(value & 0x7f)
+		value >>= 7
+		if (value == 0 && b&0x40 == 0) || (value == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func appendName(out []byte, name string) []byte {
+	out = appendULEB(out, uint64(len(name)))
+	return append(out, name...)
+}
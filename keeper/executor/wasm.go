@@ -0,0 +1,261 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+)
+
+// The WASM job ABI.
+//
+// A job module exports its linear memory as "memory" and a function
+// "run() -> i32" that returns 0 on success, and likewise "check() -> i32" if
+// its job has a scripted condition. It may import the following
+// functions from the "keeper" host module:
+//
+//	input_len() -> i32                       size of the JSON encoded Input
+//	read_input(ptr i32)                      copies the JSON encoded Input to ptr
+//	block_number() -> i64                    number of the block the job runs at
+//	block_timestamp() -> i64                 timestamp of that block
+//	eth_call(to_ptr, data_ptr, data_len, out_ptr, out_cap i32) -> i32
+//	                                         read-only call of the 20 byte address at
+//	                                         to_ptr, pinned to the same block; returns
+//	                                         the length of the return data (of which at
+//	                                         most out_cap bytes are copied) or -1
+//	set_result(ptr i32, len i32)             sets the job result bytes
+//	log(ptr i32, len i32)                    appends a line to Result.Logs
+const (
+	wasmHostModule  = "keeper"
+	wasmPageSize    = 64 << 10
+	wasmDefaultFuel = 100_000_000
+)
+
+var ErrOutOfFuel = errors.New("job ran out of fuel")
+
+// ChainReader gives jobs read-only access to chain state. *ethclient.Client
+// satisfies it.
+type ChainReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// WasmExecutor runs job modules compiled to WebAssembly in an embedded,
+// pure-Go runtime. Every instruction the module runs consumes one unit of
+// fuel (see meterModule), so a job runs out of fuel at the same point on
+// every operator, however fast its hardware. All chain reads are pinned to
+// Input.BlockNumber, or else to the block that was the head when the job
+// started, so every operator running the job at that block sees the same
+// state.
+type WasmExecutor struct {
+	limits Limits
+	fuel   uint64
+	chain  ChainReader
+}
+
+var _ Executor = (*WasmExecutor)(nil)
+
+// NewWasmExecutor creates a WasmExecutor. fuel is the number of instructions a
+// job may run; zero selects a default. chain may be nil, in which case the
+// chain host functions fail.
+func NewWasmExecutor(limits Limits, fuel uint64, chain ChainReader) *WasmExecutor {
+	if fuel == 0 {
+		fuel = wasmDefaultFuel
+	}
+	return &WasmExecutor{limits: limits, fuel: fuel, chain: chain}
+}
+
+type wasmCall struct {
+	input   []byte
+	header  *types.Header
+	chain   ChainReader
+	result  []byte
+	hasSet  bool
+	logs    []string
+	maxOut  int
+	callErr error
+}
+
+func (e *WasmExecutor) Execute(ctx context.Context, code []byte, input Input) (*Result, error) {
+	start := time.Now()
+	if e.limits.MaxExecutionTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.limits.MaxExecutionTime)
+		defer cancel()
+	}
+
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	call := &wasmCall{input: inputJSON, chain: e.chain, maxOut: e.limits.MaxOutputBytes}
+	if e.chain != nil {
+		var number *big.Int
+		if input.BlockNumber != 0 {
+			number = new(big.Int).SetUint64(input.BlockNumber)
+		}
+		call.header, err = e.chain.HeaderByNumber(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch block header for job: %w", err)
+		}
+	}
+
+	config := wazero.NewRuntimeConfigInterpreter().WithCloseOnContextDone(true)
+	if e.limits.MaxMemoryBytes > 0 {
+		config = config.WithMemoryLimitPages(uint32(e.limits.MaxMemoryBytes / wasmPageSize))
+	}
+	runtime := wazero.NewRuntimeWithConfig(ctx, config)
+	defer runtime.Close(context.Background())
+
+	if err := call.instantiateHost(ctx, runtime); err != nil {
+		return nil, err
+	}
+	metered, err := meterModule(code, e.fuel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wasm module: %w", err)
+	}
+	compiled, err := runtime.CompileModule(ctx, metered)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wasm module: %w", err)
+	}
+	mod, err := runtime.InstantiateModule(ctx, compiled, wazero.NewModuleConfig().WithStartFunctions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate wasm module: %w", err)
+	}
+	run := mod.ExportedFunction(input.entrypoint())
+	if run == nil {
+		return nil, ErrNoEntrypoint
+	}
+
+	results, err := run.Call(ctx)
+	switch {
+	case int64(mod.ExportedGlobal(wasmFuelGlobal).Get()) < 0:
+		return nil, ErrOutOfFuel
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return nil, ErrTimeLimit
+	case call.callErr != nil:
+		return nil, call.callErr
+	case err != nil:
+		return nil, err
+	}
+	if status := int32(results[0]); status != 0 {
+		return nil, fmt.Errorf("job exited with status %d", status)
+	}
+	if !call.hasSet {
+		return nil, ErrNoResult
+	}
+
+	return &Result{
+		Output:   call.result,
+		Logs:     call.logs,
+		Duration: time.Since(start),
+	}, nil
+}
+
+func (c *wasmCall) instantiateHost(ctx context.Context, runtime wazero.Runtime) error {
+	_, err := runtime.NewHostModuleBuilder(wasmHostModule).
+		NewFunctionBuilder().WithFunc(c.inputLen).Export("input_len").
+		NewFunctionBuilder().WithFunc(c.readInput).Export("read_input").
+		NewFunctionBuilder().WithFunc(c.blockNumber).Export("block_number").
+		NewFunctionBuilder().WithFunc(c.blockTimestamp).Export("block_timestamp").
+		NewFunctionBuilder().WithFunc(c.ethCall).Export("eth_call").
+		NewFunctionBuilder().WithFunc(c.setResult).Export("set_result").
+		NewFunctionBuilder().WithFunc(c.log).Export("log").
+		Instantiate(ctx)
+	return err
+}
+
+func (c *wasmCall) inputLen() uint32 {
+	return uint32(len(c.input))
+}
+
+func (c *wasmCall) readInput(ctx context.Context, mod api.Module, ptr uint32) {
+	if !mod.Memory().Write(ptr, c.input) {
+		c.abort(ctx, mod, errors.New("read_input: pointer out of range"))
+	}
+}
+
+func (c *wasmCall) blockNumber(ctx context.Context, mod api.Module) uint64 {
+	if c.header == nil {
+		c.abort(ctx, mod, errors.New("block_number: no chain reader configured"))
+		return 0
+	}
+	return c.header.Number.Uint64()
+}
+
+func (c *wasmCall) blockTimestamp(ctx context.Context, mod api.Module) uint64 {
+	if c.header == nil {
+		c.abort(ctx, mod, errors.New("block_timestamp: no chain reader configured"))
+		return 0
+	}
+	return c.header.Time
+}
+
+func (c *wasmCall) ethCall(ctx context.Context, mod api.Module, toPtr, dataPtr, dataLen, outPtr, outCap uint32) int32 {
+	if c.header == nil {
+		return -1
+	}
+	to, ok := mod.Memory().Read(toPtr, common.AddressLength)
+	if !ok {
+		return -1
+	}
+	data, ok := mod.Memory().Read(dataPtr, dataLen)
+	if !ok {
+		return -1
+	}
+	addr := common.BytesToAddress(to)
+	ret, err := c.chain.CallContract(ctx, ethereum.CallMsg{To: &addr, Data: append([]byte(nil), data...)}, c.header.Number)
+	if err != nil {
+		return -1
+	}
+	n := len(ret)
+	if uint32(n) > outCap {
+		ret = ret[:outCap]
+	}
+	if !mod.Memory().Write(outPtr, ret) {
+		return -1
+	}
+	return int32(n)
+}
+
+func (c *wasmCall) setResult(ctx context.Context, mod api.Module, ptr, length uint32) {
+	if c.maxOut > 0 && int(length) > c.maxOut {
+		c.abort(ctx, mod, ErrOutputTooLarge)
+		return
+	}
+	data, ok := mod.Memory().Read(ptr, length)
+	if !ok {
+		c.abort(ctx, mod, errors.New("set_result: pointer out of range"))
+		return
+	}
+	c.result = append([]byte(nil), data...)
+	c.hasSet = true
+}
+
+func (c *wasmCall) log(ctx context.Context, mod api.Module, ptr, length uint32) {
+	if len(c.logs) >= jsMaxLogLines {
+		return
+	}
+	data, ok := mod.Memory().Read(ptr, length)
+	if !ok {
+		c.abort(ctx, mod, errors.New("log: pointer out of range"))
+		return
+	}
+	c.logs = append(c.logs, string(data))
+}
+
+// abort records why a host function failed and stops the module.
+func (c *wasmCall) abort(ctx context.Context, mod api.Module, err error) {
+	if c.callErr == nil {
+		c.callErr = err
+	}
+	_ = mod.CloseWithExitCode(ctx, 1)
+}
@@ -0,0 +1,129 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+)
+
+// wasmSetResultModule is the binary encoding of
+//
+//	(module
+//	  (import "keeper" "set_result" (func (param i32 i32)))
+//	  (memory (export "memory") 1)
+//	  (data (i32.const 0) "ok")
+//	  (func (export "run") (result i32)
+//	    (call 0 (i32.const 0) (i32.const 2))
+//	    (i32.const 0)))
+var wasmSetResultModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x0a, 0x02, 0x60, 0x02, 0x7f, 0x7f, 0x00, 0x60, 0x00, 0x01, 0x7f,
+	0x02, 0x15, 0x01, 0x06, 'k', 'e', 'e', 'p', 'e', 'r', 0x0a, 's', 'e', 't', '_', 'r', 'e', 's', 'u', 'l', 't', 0x00, 0x00,
+	0x03, 0x02, 0x01, 0x01,
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	0x07, 0x10, 0x02, 0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00, 0x03, 'r', 'u', 'n', 0x00, 0x01,
+	0x0a, 0x0c, 0x01, 0x0a, 0x00, 0x41, 0x00, 0x41, 0x02, 0x10, 0x00, 0x41, 0x00, 0x0b,
+	0x0b, 0x08, 0x01, 0x00, 0x41, 0x00, 0x0b, 0x02, 'o', 'k',
+}
+
+// wasmSpinModule is the binary encoding of
+//
+//	(module
+//	  (memory (export "memory") 1)
+//	  (func $noop)
+//	  (func (export "run") (result i32)
+//	    (loop (call $noop) (br 0))
+//	    (i32.const 0)))
+var wasmSpinModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x08, 0x02, 0x60, 0x00, 0x00, 0x60, 0x00, 0x01, 0x7f,
+	0x03, 0x03, 0x02, 0x00, 0x01,
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	0x07, 0x10, 0x02, 0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00, 0x03, 'r', 'u', 'n', 0x00, 0x01,
+	0x0a, 0x10, 0x02, 0x02, 0x00, 0x0b, 0x0b, 0x00, 0x03, 0x40, 0x10, 0x00, 0x0c, 0x00, 0x0b, 0x41, 0x00, 0x0b,
+}
+
+// wasmCountModule is the binary encoding of
+//
+//	(module
+//	  (memory (export "memory") 1)
+//	  (global $count (mut i32) (i32.const 0))
+//	  (func (export "run") (result i32)
+//	    (loop (global.set $count (i32.add (global.get $count) (i32.const 1))) (br 0))
+//	    (i32.const 0)))
+var wasmCountModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x01, 0x05, 0x01, 0x60, 0x00, 0x01, 0x7f, 0x03,
+	0x02, 0x01, 0x00, 0x05, 0x03, 0x01, 0x00, 0x01, 0x06, 0x06, 0x01, 0x7f, 0x01, 0x41, 0x00, 0x0b,
+	0x07, 0x10, 0x02, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00, 0x03, 0x72, 0x75, 0x6e,
+	0x00, 0x00, 0x0a, 0x12, 0x01, 0x10, 0x00, 0x03, 0x40, 0x23, 0x00, 0x41, 0x01, 0x6a, 0x24, 0x00,
+	0x0c, 0x00, 0x0b, 0x41, 0x00, 0x0b,
+}
+
+func TestWasmExecutorReturnsResultBytes(t *testing.T) {
+	e := NewWasmExecutor(DefaultLimits, 0, nil)
+
+	result, err := e.Execute(context.Background(), wasmSetResultModule, Input{JobID: 1})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if string(result.Output) != "ok" {
+		t.Errorf("Output = %q, want %q", result.Output, "ok")
+	}
+}
+
+func TestWasmExecutorRunsOutOfFuel(t *testing.T) {
+	limits := DefaultLimits
+	limits.MaxExecutionTime = time.Minute
+	e := NewWasmExecutor(limits, 100_000, nil)
+
+	for name, code := range map[string][]byte{"calls": wasmSpinModule, "no calls": wasmCountModule} {
+		start := time.Now()
+		if _, err := e.Execute(context.Background(), code, Input{}); !errors.Is(err, ErrOutOfFuel) {
+			t.Errorf("%s: got err %v, want %v", name, err, ErrOutOfFuel)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Errorf("%s: job ran for %s before running out of fuel", name, elapsed)
+		}
+	}
+}
+
+func TestMeterModuleChargesEveryLoopIteration(t *testing.T) {
+	// the function's entry charges the loop, i32.const and end, and every
+	// iteration charges the six instructions of the loop's body, so the fuel
+	// left when the loop traps tells how many iterations it was charged for
+	for fuel, left := range map[uint64]int64{3 + 6*10: -6, 3 + 6*10 - 1: -1} {
+		metered, err := meterModule(wasmCountModule, fuel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := context.Background()
+		runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfigInterpreter())
+		mod, err := runtime.Instantiate(ctx, metered)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := mod.ExportedFunction("run").Call(ctx); err == nil {
+			t.Errorf("fuel %d: the loop returned", fuel)
+		}
+		if got := int64(mod.ExportedGlobal(wasmFuelGlobal).Get()); got != left {
+			t.Errorf("fuel %d: %d left, want %d", fuel, got, left)
+		}
+		runtime.Close(ctx)
+	}
+}
+
+func TestBackendForJobType(t *testing.T) {
+	for jobType, want := range map[string]Backend{
+		"wasm":             BackendWasm,
+		"WASM-rust":        BackendWasm,
+		"js":               BackendJS,
+		"Example Job Type": BackendJS,
+	} {
+		if got := BackendForJobType(jobType); got != want {
+			t.Errorf("BackendForJobType(%q) = %s, want %s", jobType, got, want)
+		}
+	}
+}
@@ -0,0 +1,424 @@
+// Package jobcode resolves a job's JobURL to a pinned copy of the job code, so
+// that every operator runs byte-identical code for a job.
+//
+// A JobURL is one of
+//
+//	https://host/path/job.js              plain download
+//	file://path/job.js                    file in the mirror dir, at <mirror dir>/path/job.js
+//	git+https://host/org/repo.git?ref=<commit>&path=<path>
+//	                                      <path> in <commit>, a full 40 hex commit id
+//
+// and may carry the expected content hash as a "#sha256=<hex>" fragment, which
+// is required unless the fetcher is configured otherwise. Git URLs are pinned
+// by their commit, so branch and tag names are rejected. The first successful
+// fetch for a job pins its URL, commit and content hash; later fetches for the
+// same job ID only ever return the pinned bytes.
+package jobcode
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	maxCodeSize        = 4 << 20
+	defaultHTTPTimeout = 30 * time.Second
+	hashFragmentPrefix = "sha256="
+	gitSchemePrefix    = "git+"
+)
+
+var (
+	ErrHashMismatch  = errors.New("job code does not match its recorded hash")
+	ErrHashRequired  = errors.New("job URL does not record a sha256 hash")
+	ErrURLChanged    = errors.New("job URL differs from the URL the job was pinned with")
+	ErrUnavailable   = errors.New("job code is not available offline")
+	ErrOutsideMirror = errors.New("job URL points outside the mirror dir")
+	ErrMutableRef    = errors.New("git job URL ref is not a commit id")
+
+	commitRe = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+type Config struct {
+	// CacheDir holds the content-addressed code store and the per-job pins.
+	CacheDir string
+	// MirrorDir holds local copies of job sources: bare git repositories at
+	// git/<host>/<repo path>, and downloaded files at http/<host>/<path>.
+	MirrorDir string
+	// Offline disables all network access; code must come from the cache or
+	// the mirror.
+	Offline bool
+	// RequireHash rejects job URLs other than git URLs that do not record a
+	// sha256 hash, instead of pinning whatever the first fetch returns. It
+	// should only be turned off for development: operators that fetch a
+	// mutable URL at different times run different code.
+	RequireHash bool
+	HTTPTimeout time.Duration
+}
+
+// Code is job code pinned to its content hash.
+type Code struct {
+	JobID  uint32 `json:"jobID"`
+	URL    string `json:"url"`
+	Commit string `json:"commit,omitempty"`
+	Hash   string `json:"sha256"`
+	Bytes  []byte `json:"-"`
+}
+
+type Fetcher struct {
+	config Config
+	client *http.Client
+}
+
+func NewFetcher(config Config) (*Fetcher, error) {
+	if config.CacheDir == "" {
+		return nil, errors.New("jobcode: cache dir is required")
+	}
+	for _, dir := range []string{objectsDir(config.CacheDir), pinsDir(config.CacheDir)} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	timeout := config.HTTPTimeout
+	if timeout == 0 {
+		timeout = defaultHTTPTimeout
+	}
+	return &Fetcher{config: config, client: &http.Client{Timeout: timeout}}, nil
+}
+
+// Fetch returns the code for a job, fetching and pinning it on first use.
+func (f *Fetcher) Fetch(ctx context.Context, jobID uint32, jobURL string) (*Code, error) {
+	if pin, err := f.readPin(jobID); err == nil {
+		if pin.URL != jobURL {
+			return nil, ErrURLChanged
+		}
+		pin.Bytes, err = f.readObject(pin.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("pinned code for job %d is unreadable: %w", jobID, err)
+		}
+		return pin, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	location, expectedHash, err := splitHash(jobURL)
+	if err != nil {
+		return nil, err
+	}
+	if expectedHash == "" && f.config.RequireHash && !strings.HasPrefix(location, gitSchemePrefix) {
+		return nil, ErrHashRequired
+	}
+
+	code := &Code{JobID: jobID, URL: jobURL}
+	switch {
+	case strings.HasPrefix(location, gitSchemePrefix):
+		code.Commit, code.Bytes, err = f.fetchGit(ctx, strings.TrimPrefix(location, gitSchemePrefix))
+	case strings.HasPrefix(location, "http://"), strings.HasPrefix(location, "https://"):
+		code.Bytes, err = f.fetchHTTP(ctx, location)
+	default:
+		code.Bytes, err = f.fetchFile(location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch code for job %d from %s: %w", jobID, location, err)
+	}
+
+	sum := sha256.Sum256(code.Bytes)
+	code.Hash = hex.EncodeToString(sum[:])
+	if expectedHash != "" && expectedHash != code.Hash {
+		return nil, fmt.Errorf("%w: got %s, want %s", ErrHashMismatch, code.Hash, expectedHash)
+	}
+
+	if err := f.writeObject(code.Hash, code.Bytes); err != nil {
+		return nil, err
+	}
+	if err := f.writePin(code); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+func splitHash(jobURL string) (string, string, error) {
+	location, fragment, found := strings.Cut(jobURL, "#")
+	if !found {
+		return location, "", nil
+	}
+	if !strings.HasPrefix(fragment, hashFragmentPrefix) {
+		return "", "", fmt.Errorf("unsupported job URL fragment %q", fragment)
+	}
+	hash := strings.ToLower(strings.TrimPrefix(fragment, hashFragmentPrefix))
+	if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != sha256.Size {
+		return "", "", fmt.Errorf("invalid sha256 hash %q in job URL", hash)
+	}
+	return location, hash, nil
+}
+
+// fetchFile reads a file from the mirror dir. Job URLs are set by whoever
+// creates the job, so they must never reach other files of the operator's host.
+func (f *Fetcher) fetchFile(location string) ([]byte, error) {
+	location = strings.TrimPrefix(location, "file://")
+	if filepath.IsAbs(location) {
+		rel, err := filepath.Rel(f.config.MirrorDir, location)
+		if f.config.MirrorDir == "" || err != nil || !filepath.IsLocal(rel) {
+			return nil, fmt.Errorf("%w: %q", ErrOutsideMirror, location)
+		}
+		location = filepath.ToSlash(rel)
+	}
+	path, err := f.mirrorPath(location)
+	if err != nil {
+		return nil, err
+	}
+	return readLimited(path)
+}
+
+// mirrorPath joins the slash separated parts of a path inside the mirror dir.
+// Parts with ".." segments are rejected, and so are paths that leave the
+// mirror dir through a symlink.
+func (f *Fetcher) mirrorPath(parts ...string) (string, error) {
+	if f.config.MirrorDir == "" {
+		return "", fmt.Errorf("%w: no mirror dir is configured", ErrOutsideMirror)
+	}
+	elems := []string{f.config.MirrorDir}
+	for _, part := range parts {
+		for _, segment := range strings.Split(part, "/") {
+			if segment == ".." || strings.ContainsRune(segment, filepath.Separator) {
+				return "", fmt.Errorf("%w: %q", ErrOutsideMirror, part)
+			}
+			elems = append(elems, segment)
+		}
+	}
+	path := filepath.Join(elems...)
+	root, err := filepath.EvalSymlinks(f.config.MirrorDir)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// nothing to follow yet, e.g. a repository that is still to be cloned
+		return path, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if rel, err := filepath.Rel(root, resolved); err != nil || !(rel == "." || filepath.IsLocal(rel)) {
+		return "", fmt.Errorf("%w: %q", ErrOutsideMirror, path)
+	}
+	return resolved, nil
+}
+
+// fetchHTTP prefers the mirror and only goes to the network when the file
+// is not mirrored.
+func (f *Fetcher) fetchHTTP(ctx context.Context, location string) ([]byte, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if f.config.MirrorDir != "" {
+		path, err := f.mirrorPath("http", u.Host, u.Path)
+		if err != nil {
+			return nil, err
+		}
+		data, err := readLimited(path)
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	if f.config.Offline {
+		return nil, ErrUnavailable
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return readAllLimited(resp.Body)
+}
+
+// fetchGit resolves ref to a commit in the local mirror of the repository,
+// cloning or updating the mirror first if the ref is not known yet.
+func (f *Fetcher) fetchGit(ctx context.Context, location string) (string, []byte, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", nil, err
+	}
+	ref, path := u.Query().Get("ref"), u.Query().Get("path")
+	if ref == "" || path == "" {
+		return "", nil, errors.New("git job URLs need both a ref and a path query parameter")
+	}
+	if !commitRe.MatchString(ref) {
+		return "", nil, fmt.Errorf("%w: %q", ErrMutableRef, ref)
+	}
+	if f.config.MirrorDir == "" {
+		return "", nil, errors.New("git job URLs need a mirror dir")
+	}
+	u.RawQuery = ""
+	repoDir, err := f.mirrorPath("git", u.Host, u.Path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	commit, err := resolveCommit(ctx, repoDir, ref)
+	if err != nil {
+		if f.config.Offline {
+			return "", nil, ErrUnavailable
+		}
+		if err := updateMirror(ctx, repoDir, u.String()); err != nil {
+			return "", nil, err
+		}
+		if commit, err = resolveCommit(ctx, repoDir, ref); err != nil {
+			return "", nil, err
+		}
+	}
+
+	data, err := git(ctx, repoDir, "show", commit+":"+path)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(data) > maxCodeSize {
+		return "", nil, fmt.Errorf("job code is larger than %d bytes", maxCodeSize)
+	}
+	return commit, data, nil
+}
+
+func resolveCommit(ctx context.Context, repoDir, ref string) (string, error) {
+	if _, err := os.Stat(repoDir); err != nil {
+		return "", err
+	}
+	out, err := git(ctx, repoDir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+	commit := strings.TrimSpace(string(out))
+	if !commitRe.MatchString(commit) {
+		return "", fmt.Errorf("ref %q did not resolve to a commit", ref)
+	}
+	return commit, nil
+}
+
+func updateMirror(ctx context.Context, repoDir, remote string) error {
+	if _, err := os.Stat(repoDir); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(repoDir), 0o755); err != nil {
+			return err
+		}
+		cmd := exec.CommandContext(ctx, "git", "clone", "--mirror", "--quiet", remote, repoDir)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git clone %s: %w: %s", remote, err, bytes.TrimSpace(out))
+		}
+		return nil
+	}
+	_, err := git(ctx, repoDir, "fetch", "--quiet", "--prune", "origin")
+	return err
+}
+
+func git(ctx context.Context, repoDir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"--git-dir", repoDir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return out, nil
+}
+
+func readLimited(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readAllLimited(file)
+}
+
+func readAllLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxCodeSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxCodeSize {
+		return nil, fmt.Errorf("job code is larger than %d bytes", maxCodeSize)
+	}
+	return data, nil
+}
+
+func objectsDir(cacheDir string) string {
+	return filepath.Join(cacheDir, "objects", "sha256")
+}
+
+func pinsDir(cacheDir string) string {
+	return filepath.Join(cacheDir, "jobs")
+}
+
+func (f *Fetcher) readObject(hash string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(objectsDir(f.config.CacheDir), hash))
+	if err != nil {
+		return nil, err
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != hash {
+		return nil, ErrHashMismatch
+	}
+	return data, nil
+}
+
+func (f *Fetcher) writeObject(hash string, data []byte) error {
+	return writeFileAtomic(filepath.Join(objectsDir(f.config.CacheDir), hash), data)
+}
+
+func (f *Fetcher) readPin(jobID uint32) (*Code, error) {
+	data, err := os.ReadFile(filepath.Join(pinsDir(f.config.CacheDir), fmt.Sprintf("%d.json", jobID)))
+	if err != nil {
+		return nil, err
+	}
+	var pin Code
+	if err := json.Unmarshal(data, &pin); err != nil {
+		return nil, fmt.Errorf("corrupt pin for job %d: %w", jobID, err)
+	}
+	return &pin, nil
+}
+
+func (f *Fetcher) writePin(code *Code) error {
+	data, err := json.MarshalIndent(code, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(pinsDir(f.config.CacheDir), fmt.Sprintf("%d.json", code.JobID)), data)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,146 @@
+package jobcode
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testScript = `function run() { return 1; }`
+
+func testHash(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestFetchPinsFileCode(t *testing.T) {
+	dir := t.TempDir()
+	mirror := filepath.Join(dir, "mirror")
+	if err := os.MkdirAll(mirror, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	scriptPath := filepath.Join(mirror, "job.js")
+	if err := os.WriteFile(scriptPath, []byte(testScript), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFetcher(Config{CacheDir: filepath.Join(dir, "cache"), MirrorDir: mirror, RequireHash: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Fetch(context.Background(), 1, "file://job.js"); !errors.Is(err, ErrHashRequired) {
+		t.Errorf("got err %v, want %v", err, ErrHashRequired)
+	}
+	jobURL := "file://job.js#sha256=" + testHash(testScript)
+
+	code, err := f.Fetch(context.Background(), 1, jobURL)
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	if string(code.Bytes) != testScript {
+		t.Errorf("Bytes = %q, want %q", code.Bytes, testScript)
+	}
+
+	// Once pinned, the job keeps running the same bytes even if the source changes.
+	if err := os.WriteFile(scriptPath, []byte(`function run() { return 2; }`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, err = f.Fetch(context.Background(), 1, jobURL)
+	if err != nil {
+		t.Fatalf("Fetch of pinned job returned error: %v", err)
+	}
+	if string(code.Bytes) != testScript {
+		t.Errorf("pinned Bytes = %q, want %q", code.Bytes, testScript)
+	}
+
+	if _, err := f.Fetch(context.Background(), 1, "file://job.js"); !errors.Is(err, ErrURLChanged) {
+		t.Errorf("got err %v, want %v", err, ErrURLChanged)
+	}
+	if _, err := f.Fetch(context.Background(), 2, jobURL); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("got err %v, want %v", err, ErrHashMismatch)
+	}
+}
+
+func TestFetchGitFromOfflineMirror(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	work := filepath.Join(dir, "work")
+	runGit(t, "", "init", "--quiet", work)
+	if err := os.WriteFile(filepath.Join(work, "job.js"), []byte(testScript), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, work, "add", "job.js")
+	runGit(t, work, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "job")
+	commit := strings.TrimSpace(runGit(t, work, "rev-parse", "HEAD"))
+
+	mirror := filepath.Join(dir, "mirror")
+	runGit(t, "", "clone", "--mirror", "--quiet", work, filepath.Join(mirror, "git", "example.com", "org", "jobs.git"))
+
+	f, err := NewFetcher(Config{CacheDir: filepath.Join(dir, "cache"), MirrorDir: mirror, Offline: true, RequireHash: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Fetch(context.Background(), 1, "git+https://example.com/org/jobs.git?ref=HEAD&path=job.js"); !errors.Is(err, ErrMutableRef) {
+		t.Errorf("got err %v, want %v", err, ErrMutableRef)
+	}
+	code, err := f.Fetch(context.Background(), 1, "git+https://example.com/org/jobs.git?ref="+commit+"&path=job.js")
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	if code.Commit != commit {
+		t.Errorf("Commit = %s, want %s", code.Commit, commit)
+	}
+	if string(code.Bytes) != testScript {
+		t.Errorf("Bytes = %q, want %q", code.Bytes, testScript)
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func TestFetchStaysInsideMirror(t *testing.T) {
+	dir := t.TempDir()
+	mirror := filepath.Join(dir, "mirror")
+	if err := os.MkdirAll(filepath.Join(mirror, "http", "example.com"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	secret := filepath.Join(dir, "secret")
+	if err := os.WriteFile(secret, []byte("key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(secret, filepath.Join(mirror, "link.js")); err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFetcher(Config{CacheDir: filepath.Join(dir, "cache"), MirrorDir: mirror, Offline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, jobURL := range []string{
+		"file://" + secret,
+		secret,
+		"file://../secret",
+		"link.js",
+		"https://example.com/../../secret",
+		"https://example.com/%2e%2e/%2e%2e/secret",
+		"git+https://../secret?ref=" + strings.Repeat("a", 40) + "&path=job.js",
+	} {
+		if _, err := f.Fetch(context.Background(), uint32(i), jobURL); !errors.Is(err, ErrOutsideMirror) {
+			t.Errorf("Fetch(%q) error = %v, want %v", jobURL, err, ErrOutsideMirror)
+		}
+	}
+}
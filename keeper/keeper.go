@@ -2,106 +2,808 @@ package main
 
 import (
     "context"
-    "crypto/ecdsa"
     "encoding/json"
+    "errors"
     "fmt"
-    "io/ioutil"
+    "io"
     "log"
     "math/big"
     "net/http"
     "os"
+    "os/signal"
+    "runtime/debug"
+    "strconv"
+    "strings"
+    "sync"
+    "syscall"
     "time"
-    "bytes"
 
     "github.com/ethereum/go-ethereum/common"
+    gethtypes "github.com/ethereum/go-ethereum/core/types"
     "github.com/ethereum/go-ethereum/crypto"
     "github.com/ethereum/go-ethereum/ethclient"
-    "github.com/ethereum/go-ethereum/core/types"
     "github.com/joho/godotenv"
-    blst "github.com/supranational/blst/bindings/go"
-    "github.com/Keeper-network-2/keeper/keeper"
-    "github.com/Keeper-network-2/keeper/aggregator"
+    "github.com/Layr-Labs/incredible-squaring-avs/aggregator"
+    "github.com/Layr-Labs/eigensdk-go/crypto/bls"
+    "github.com/Layr-Labs/eigensdk-go/signerv2"
+    sdktypes "github.com/Layr-Labs/eigensdk-go/types"
+    sdkutils "github.com/Layr-Labs/eigensdk-go/utils"
+    "github.com/Layr-Labs/incredible-squaring-avs/core"
+    "github.com/Layr-Labs/incredible-squaring-avs/keeper/aggregatorclient"
+    "github.com/Layr-Labs/incredible-squaring-avs/keeper/condition"
+    "github.com/Layr-Labs/incredible-squaring-avs/keeper/executor"
+    "github.com/Layr-Labs/incredible-squaring-avs/keeper/jobcode"
+    "github.com/Layr-Labs/incredible-squaring-avs/keeper/ledger"
+    "github.com/Layr-Labs/incredible-squaring-avs/keeper/upkeep"
+    "github.com/Layr-Labs/incredible-squaring-avs/keeper/workerpool"
+    keepertypes "github.com/Layr-Labs/incredible-squaring-avs/types"
     /* "github.com/yourorg/yourproject/logging"
     "github.com/yourorg/yourproject/metrics" */
 )
 
 type JobCreatedEvent struct {
     JobID          uint32 `json:"jobID"`
+    TaskID         uint32 `json:"taskID"`
     JobType        string `json:"jobType"`
     JobDescription string `json:"jobDescription"`
     JobURL         string `json:"jobURL"`
+    // Args is the input of the job, e.g. the event that triggered it.
+    Args           map[string]interface{} `json:"args,omitempty"`
+    // BlockNumber is the head when the task was created, which the job's
+    // check is evaluated from.
+    BlockNumber    uint64 `json:"blockNumber,omitempty"`
+    // SubmitUpkeep is set for the operator the task is assigned to, which
+    // submits the call of an upkeep job. The others only attest to the call.
+    SubmitUpkeep   bool `json:"submitUpkeep,omitempty"`
 }
 
-var rpcClient *operator.AggregatorRpcClient
+// Signed task responses go through an on-disk outbox, so they reach the
+// aggregator even if it or the keeper restarts in between.
+var aggregatorClient *aggregatorclient.Client
+
+// Job code runs in a sandbox without filesystem or network access. The
+// backend is picked from the job's JobType.
+var jobExecutors executor.Registry
+
+// Job code is fetched from the job's JobURL and pinned per job ID. The URL has
+// to record the code's hash or a git commit, unless JOB_CODE_REQUIRE_HASH is
+// false.
+var jobCodeFetcher *jobcode.Fetcher
+
+// Jobs may declare a check that has to hold before they run. It is evaluated
+// from the task's block until it does, or until the blocks of conditionWindow
+// are over and the task is answered as skipped. The window should end before
+// the task manager's task_timeout. Jobs wait for their check outside the
+// pool, so that waiting does not hold a worker, until conditionCtx ends at
+// shutdown.
+var conditionEvaluator *condition.Evaluator
+var conditionWindow time.Duration
+var conditionCtx context.Context
+var conditionWaits sync.WaitGroup
+
+// The chain jobs run against. Job code sees the timestamp of the task's block
+// as the current time, so that every operator computes the same result.
+var chainReader condition.ChainReader
+
+// Jobs run on a bounded pool of workers, so that a slow job does not hold up
+// the task manager and a burst of tasks does not overload the operator.
+var jobPool *workerpool.Pool
+
+// Every execution is recorded in a local ledger, which also dedupes the tasks
+// the task manager resends: a task that is running is not run again, and a
+// task that was signed gets its signed response sent again.
+var executionLedger *ledger.Ledger
+
+// Upkeep jobs return a call, which is submitted from the operator's ECDSA
+// key if it goes to one of UPKEEP_ALLOWED_TARGETS and sends at most
+// UPKEEP_MAX_VALUE wei. nil if the node config has no ECDSA keystore.
+var upkeepSubmitter *upkeep.Submitter
+
+// upkeepResendTimeout bounds the wait for the receipt of an upkeep
+// transaction sent for a task received before.
+const upkeepResendTimeout = 5 * time.Minute
+
+// The operator's BN254 key, which the aggregator and the BLSSignatureChecker
+// verify task responses against.
+var blsKeyPair *bls.KeyPair
+var operatorId sdktypes.OperatorId
+
+// Task responses are signed for one chain and task manager only.
+var taskResponseDomain core.TaskResponseDomain
 
 func main() {
+    // the keeper starts itself again to run JS jobs under a memory limit
+    executor.RunJSChild()
+
     // Load environment variables from .env file
     err := godotenv.Load()
     if (err != nil) {
         log.Fatal("Error loading .env file")
     }
 
-    aggregatorIpPortAddr := os.Getenv("AGGREGATOR_IP_PORT")
-    rpcClient, err = operator.NewAggregatorRpcClient(aggregatorIpPortAddr)
+    var nodeConfig keepertypes.NodeConfig
+    configPath := envOrDefault("CONFIG", "config-files/operator.anvil.yaml")
+    if err := sdkutils.ReadYamlConfig(configPath, &nodeConfig); err != nil {
+        log.Fatalf("Error reading node config %s: %v", configPath, err)
+    }
+
+    blsKeyPair, err = bls.ReadPrivateKeyFromFile(nodeConfig.BlsPrivateKeyStorePath, os.Getenv("BLS_KEY_PASSWORD"))
+    if (err != nil) {
+        log.Fatalf("Error reading BLS key from %s: %v", nodeConfig.BlsPrivateKeyStorePath, err)
+    }
+    operatorId = sdktypes.OperatorIdFromKeyPair(blsKeyPair)
+    log.Printf("Loaded BLS key for operator %x", operatorId)
+
+    aggregatorClient, err = aggregatorclient.NewClient(aggregatorclient.Config{
+        AggregatorIpPortAddr: os.Getenv("AGGREGATOR_IP_PORT"),
+        OutboxDir:            envOrDefault("AGGREGATOR_OUTBOX_DIR", "aggregator-outbox"),
+    })
+    if (err != nil) {
+        log.Fatalf("Error creating aggregator client: %v", err)
+    }
+    go aggregatorClient.Run(context.Background())
+
+    ethClient, err := ethclient.Dial(nodeConfig.EthRpcUrl)
+    if (err != nil) {
+        log.Fatalf("Error connecting to eth node: %v", err)
+    }
+    chainId, err := ethClient.ChainID(context.Background())
+    if (err != nil) {
+        log.Fatalf("Error getting chain id: %v", err)
+    }
+    if (!common.IsHexAddress(nodeConfig.TaskManagerAddress)) {
+        log.Fatalf("Invalid task_manager_address %q in %s", nodeConfig.TaskManagerAddress, configPath)
+    }
+    taskResponseDomain = core.TaskResponseDomain{
+        ChainId:     chainId,
+        TaskManager: common.HexToAddress(nodeConfig.TaskManagerAddress),
+    }
+    upkeepSubmitter, err = newUpkeepSubmitter(nodeConfig, ethClient, chainId)
+    if (err != nil) {
+        log.Fatalf("Error creating upkeep submitter: %v", err)
+    }
+    jobCodeFetcher, err = jobcode.NewFetcher(jobcode.Config{
+        CacheDir:    envOrDefault("JOB_CODE_CACHE_DIR", "jobcode-cache"),
+        MirrorDir:   os.Getenv("JOB_CODE_MIRROR_DIR"),
+        Offline:     os.Getenv("JOB_CODE_OFFLINE") == "true",
+        RequireHash: os.Getenv("JOB_CODE_REQUIRE_HASH") != "false",
+    })
+    if (err != nil) {
+        log.Fatalf("Error creating job code fetcher: %v", err)
+    }
+
+    jobExecutors = executor.Registry{
+        executor.BackendJS:   executor.NewJSExecutor(executor.DefaultLimits),
+        executor.BackendWasm: executor.NewWasmExecutor(executor.DefaultLimits, 0, ethClient),
+    }
+
+    chainReader = ethClient
+    conditionEvaluator = condition.NewEvaluator(ethClient, time.Second)
+    conditionWindow, err = time.ParseDuration(envOrDefault("CONDITION_CHECK_WINDOW", "4m"))
     if (err != nil) {
-        log.Fatalf("Error creating RPC client: %v", err)
+        log.Fatalf("Invalid CONDITION_CHECK_WINDOW: %v", err)
+    }
+    var stopConditionWaits context.CancelFunc
+    conditionCtx, stopConditionWaits = context.WithCancel(context.Background())
+
+    workers, err := strconv.Atoi(envOrDefault("WORKER_POOL_SIZE", "0"))
+    if (err != nil) {
+        log.Fatalf("Invalid WORKER_POOL_SIZE: %v", err)
+    }
+    queueSize, err := strconv.Atoi(envOrDefault("WORKER_QUEUE_SIZE", "0"))
+    if (err != nil) {
+        log.Fatalf("Invalid WORKER_QUEUE_SIZE: %v", err)
+    }
+    jobTimeout, err := time.ParseDuration(envOrDefault("JOB_TIMEOUT", "5m"))
+    if (err != nil) {
+        log.Fatalf("Invalid JOB_TIMEOUT: %v", err)
+    }
+    jobPool = workerpool.New(workerpool.Config{Workers: workers, QueueSize: queueSize, Timeout: jobTimeout})
+
+    executionLedger, err = ledger.Open(envOrDefault("EXECUTION_LEDGER_PATH", "keeper-ledger.db"))
+    if (err != nil) {
+        log.Fatalf("Error opening the execution ledger: %v", err)
     }
 
     http.HandleFunc("/executeTask", executeTaskHandler)
-    log.Println("Starting operator server on port 8081...")
-    log.Fatal(http.ListenAndServe(":8081", nil))
+    server := &http.Server{Addr: ":8081"}
+    go func() {
+        log.Println("Starting operator server on port 8081...")
+        if err := server.ListenAndServe(); (err != nil && !errors.Is(err, http.ErrServerClosed)) {
+            log.Fatal(err)
+        }
+    }()
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+    <-ctx.Done()
+    log.Println("Shutting down, waiting for the running jobs to finish...")
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    defer cancel()
+    if err := server.Shutdown(shutdownCtx); (err != nil) {
+        log.Printf("Error shutting down the operator server: %v", err)
+    }
+    stopConditionWaits()
+    conditionWaits.Wait()
+    if err := jobPool.Shutdown(shutdownCtx); (err != nil) {
+        log.Printf("Cancelled the jobs that were still running: %v", err)
+    }
+    if err := executionLedger.Close(); (err != nil) {
+        log.Printf("Error closing the execution ledger: %v", err)
+    }
 }
 
 func executeTaskHandler(w http.ResponseWriter, r *http.Request) {
+    receivedAt := time.Now()
+    body, err := io.ReadAll(r.Body)
+    if (err != nil) {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
     var job JobCreatedEvent
-    if (err := json.NewDecoder(r.Body).Decode(&job)) != nil {
+    if err := json.Unmarshal(body, &job); (err != nil) {
         http.Error(w, err.Error(), http.StatusBadRequest)
         return
     }
 
     log.Printf("Received task: %+v\n", job)
 
-    // Perform task execution logic here
-    executeJob(job.JobID)
+    check, err := condition.Parse(job.JobDescription)
+    if (err != nil) {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+    priority, timeout, err := jobSettings(job.JobDescription)
+    if (err != nil) {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    key := ledger.Key{JobID: job.JobID, TaskID: job.TaskID, TriggerBlock: triggerBlock(job)}
+    execution, started, err := executionLedger.Begin(key, body, receivedAt)
+    if (err != nil) {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    if (!started) {
+        log.Printf("Task %s was received before and is %s, not running it again", key, execution.Status)
+        if (execution.Status == ledger.StatusSigned) {
+            go sendResult(execution)
+        }
+        if (len(execution.UpkeepTx) > 0) {
+            go resendUpkeep(execution)
+        } else if (job.SubmitUpkeep && upkeep.IsUpkeepJobType(job.JobType) && execution.Status == ledger.StatusSigned && execution.TaskResponse.Status == core.TaskStatusSucceeded) {
+            // the operator attested to the task, and was given it since
+            go func() {
+                ctx, cancel := context.WithTimeout(context.Background(), upkeepResendTimeout)
+                defer cancel()
+                submitUpkeep(ctx, execution)
+            }()
+        }
+        w.WriteHeader(http.StatusOK)
+        return
+    }
+
+    if (check != nil) {
+        conditionWaits.Add(1)
+        go func() {
+            defer conditionWaits.Done()
+            waitAndSubmit(job, check, execution, priority, timeout)
+        }()
+        w.WriteHeader(http.StatusOK)
+        return
+    }
+
+    // The job runs on the pool, its response goes to the aggregator once it is done
+    err = submitJob(job, nil, execution, priority, timeout)
+    if (err != nil) {
+        finishFailed(execution, err)
+    }
+    if (errors.Is(err, workerpool.ErrSaturated)) {
+        retryAfter := jobPool.RetryAfter()
+        log.Printf("Turning down job %d, all workers are busy", job.JobID)
+        w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Round(time.Second)/time.Second)))
+        http.Error(w, err.Error(), http.StatusTooManyRequests)
+        return
+    }
+    if (err != nil) {
+        http.Error(w, err.Error(), http.StatusServiceUnavailable)
+        return
+    }
 
     w.WriteHeader(http.StatusOK)
 }
 
-func executeJob(jobID uint32) {
-    script, err := ioutil.ReadFile("script.js")
+// jobSettings reads how a job is run on the pool from its description: the
+// "priority:" line, an integer where higher runs first (default 0), and the
+// "timeout:" line, which overrides JOB_TIMEOUT.
+func jobSettings(description string) (int, time.Duration, error) {
+    var priority int
+    var timeout time.Duration
+    for _, line := range strings.Split(description, "\n") {
+        key, value, ok := strings.Cut(line, ":")
+        if (!ok) {
+            continue
+        }
+        var err error
+        switch strings.ToLower(strings.TrimSpace(key)) {
+        case "priority":
+            priority, err = strconv.Atoi(strings.TrimSpace(value))
+        case "timeout":
+            timeout, err = time.ParseDuration(strings.TrimSpace(value))
+            if (err == nil && timeout <= 0) {
+                err = errors.New("must be positive")
+            }
+        }
+        if (err != nil) {
+            return 0, 0, fmt.Errorf("invalid %s %q: %w", strings.TrimSpace(key), strings.TrimSpace(value), err)
+        }
+    }
+    return priority, timeout, nil
+}
+
+func submitJob(job JobCreatedEvent, checkResult *condition.Result, execution ledger.Execution, priority int, timeout time.Duration) error {
+    return jobPool.Submit(workerpool.Job{
+        Name:     fmt.Sprintf("%d (task %d)", job.JobID, job.TaskID),
+        Priority: priority,
+        Timeout:  timeout,
+        Run: func(ctx context.Context) {
+            executeJob(ctx, job, checkResult, execution)
+        },
+    })
+}
+
+// waitAndSubmit waits for the job's check to hold, then runs the job on the
+// pool. A task whose check does not hold is answered as skipped. The task was
+// accepted already, so a saturated pool is waited for rather than turned down.
+func waitAndSubmit(job JobCreatedEvent, check *condition.Spec, execution ledger.Execution, priority int, timeout time.Duration) {
+    // a check that panics fails the task, like a job that does
+    defer func() {
+        if r := recover(); (r != nil) {
+            recoverJob(job.JobID, execution, r)
+        }
+    }()
+    checkResult, err := waitForCondition(conditionCtx, job, check)
+    if (conditionCtx.Err() != nil) {
+        finishFailed(execution, fmt.Errorf("cancelled while waiting for its check: %w", conditionCtx.Err()))
+        return
+    }
+    if (errors.Is(err, condition.ErrWindowOver)) {
+        log.Printf("Check of job %d did not hold: %v", job.JobID, err)
+        execution.Output = checkResult.Output
+        taskResponse := newTaskResponse(job, core.TaskStatusSkipped, nil)
+        setCheckResult(taskResponse, checkResult)
+        respond(job, taskResponse, execution)
+        return
+    }
     if (err != nil) {
-        log.Printf("Error reading script file: %v", err)
+        log.Printf("Error waiting for the check of job %d: %v", job.JobID, err)
+        finishFailed(execution, err)
         return
     }
+    log.Printf("Check of job %d holds at block %d", job.JobID, checkResult.Block)
 
-    encodedData := string(script)
-    log.Printf("Encoded data from script: %s", encodedData)
+    for {
+        err = submitJob(job, &checkResult, execution, priority, timeout)
+        if (!errors.Is(err, workerpool.ErrSaturated)) {
+            break
+        }
+        select {
+        case <-conditionCtx.Done():
+            err = conditionCtx.Err()
+        case <-time.After(jobPool.RetryAfter()):
+            continue
+        }
+        break
+    }
+    if (err != nil) {
+        log.Printf("Error submitting job %d: %v", job.JobID, err)
+        finishFailed(execution, err)
+    }
+}
 
-    signedData := signJobResult(encodedData)
-    sendSignedResultToAggregator(signedData, jobID)
+// recoverJob fails the execution of a job that panicked, unless its response
+// was signed already, so that the task may be run again. The ledger tells,
+// since the panic may come after respond recorded the response but before it
+// returned.
+func recoverJob(jobID uint32, execution ledger.Execution, r interface{}) {
+    log.Printf("Job %d panicked: %v\n%s", jobID, r, debug.Stack())
+    recorded, err := executionLedger.Execution(execution.Key, execution.Attempt)
+    if (err != nil) {
+        log.Printf("Error reading %s from the ledger: %v", execution.Key, err)
+        recorded = execution
+    }
+    if (recorded.Status != ledger.StatusSigned) {
+        finishFailed(execution, fmt.Errorf("job panicked: %v", r))
+    }
 }
 
-func signJobResult(encodedData string) string {
-    privateKey := os.Getenv("BLS_PRIVATE_KEY")
+func executeJob(ctx context.Context, job JobCreatedEvent, checkResult *condition.Result, execution ledger.Execution) {
+    defer func() {
+        if r := recover(); (r != nil) {
+            recoverJob(job.JobID, execution, r)
+        }
+    }()
+    execution.StartedAt = time.Now()
+    taskResponse, err := runJob(ctx, job, checkResult, &execution)
+    if (err != nil) {
+        log.Printf("Error running job %d: %v", job.JobID, err)
+        finishFailed(execution, err)
+        return
+    }
+    var ok bool
+    execution, ok = respond(job, taskResponse, execution)
+    if (!ok) {
+        return
+    }
+    if (job.SubmitUpkeep && upkeep.IsUpkeepJobType(job.JobType) && taskResponse.Status == core.TaskStatusSucceeded) {
+        submitUpkeep(ctx, execution)
+    }
+}
 
-    // Sign the encoded data using BLS
-    privKey := blst.SecretKey{}
-    privKey.DeserializeHexStr(privateKey)
+// runJob runs the job and returns its task response. checkResult is the
+// evaluation the job's check held at, nil if it has none. What the job's code
+// returned is kept in the execution for the ledger.
+func runJob(ctx context.Context, job JobCreatedEvent, checkResult *condition.Result, execution *ledger.Execution) (*core.TaskResponse, error) {
+    jobID := job.JobID
+    jobExecutor, err := jobExecutors.ForJobType(job.JobType)
+    if (err != nil) {
+        return nil, fmt.Errorf("selecting executor: %w", err)
+    }
+
+    code, err := jobCodeFetcher.Fetch(ctx, jobID, job.JobURL)
+    if (err != nil) {
+        return nil, fmt.Errorf("fetching code: %w", err)
+    }
+    execution.CodeHash = code.Hash
+
+    input, err := jobInput(ctx, job, checkResult)
+    if (err != nil) {
+        return nil, err
+    }
+
+    log.Printf("Running job %d code %s (commit %q)", jobID, code.Hash, code.Commit)
+    result, err := jobExecutor.Execute(ctx, code.Bytes, input)
+    if (err != nil) {
+        return nil, fmt.Errorf("executing: %w", err)
+    }
+    for _, line := range result.Logs {
+        log.Printf("[job %d] %s", jobID, line)
+    }
+    log.Printf("Job %d returned %s in %s", jobID, result.Output, result.Duration)
+    execution.Output = result.Output
+    execution.Logs = result.Logs
+
+    taskResponse := newTaskResponse(job, core.TaskStatusSucceeded, result.Output)
+    if (upkeep.IsUpkeepJobType(job.JobType)) {
+        taskResponse.Status = upkeepStatus(jobID, result.Output)
+    }
+    if (checkResult != nil) {
+        setCheckResult(taskResponse, *checkResult)
+    }
+    return taskResponse, nil
+}
+
+// jobInput is the input the job's code runs with. The code runs at the block
+// its check held at, or else the block of the event that triggered the task,
+// or else the block the task was created at, so that every operator running
+// the task reads the same state and time.
+func jobInput(ctx context.Context, job JobCreatedEvent, checkResult *condition.Result) (executor.Input, error) {
+    input := executor.Input{JobID: job.JobID, TaskID: job.TaskID, Args: job.Args}
+    switch {
+    case (checkResult != nil):
+        input.BlockNumber = checkResult.Block
+    case (triggerBlock(job) != 0):
+        input.BlockNumber = triggerBlock(job)
+    default:
+        // 0 if the task manager does not give the task's block
+        input.BlockNumber = job.BlockNumber
+    }
+    var err error
+    input.BlockTimestamp, err = blockTimestamp(ctx, input.BlockNumber)
+    return input, err
+}
+
+// triggerBlock is the block of the event the task was created for, 0 if it
+// was not triggered by an event.
+func triggerBlock(job JobCreatedEvent) uint64 {
+    event, ok := job.Args["event"].(map[string]interface{})
+    if (!ok) {
+        return 0
+    }
+    blockNumber, _ := event["blockNumber"].(float64)
+    return uint64(blockNumber)
+}
+
+// waitForCondition evaluates the job's check from the task's block until it
+// holds or the condition window is over. Scripted checks call the check
+// entrypoint of the job's code.
+func waitForCondition(ctx context.Context, job JobCreatedEvent, check *condition.Spec) (condition.Result, error) {
+    // the window is counted in blocks, which a stalled chain does not make
+    ctx, cancel := context.WithTimeout(ctx, 2*conditionWindow)
+    defer cancel()
+    start := triggerBlock(job)
+    if (start == 0) {
+        start = job.BlockNumber
+    }
+    if (start == 0) {
+        // sent by a task manager that does not give the task's block
+        header, err := chainReader.HeaderByNumber(ctx, nil)
+        if (err != nil) {
+            return condition.Result{}, fmt.Errorf("failed to get the head block: %w", err)
+        }
+        start = header.Number.Uint64()
+    }
+    var script condition.ScriptFunc
+    if (check.Kind == condition.KindScript) {
+        jobExecutor, err := jobExecutors.ForJobType(job.JobType)
+        if (err != nil) {
+            return condition.Result{}, fmt.Errorf("selecting executor: %w", err)
+        }
+        code, err := jobCodeFetcher.Fetch(ctx, job.JobID, job.JobURL)
+        if (err != nil) {
+            return condition.Result{}, fmt.Errorf("fetching code: %w", err)
+        }
+        script = checkScript(job, jobExecutor, code.Bytes)
+    }
+    return conditionEvaluator.Wait(ctx, check, script, start, conditionWindow)
+}
+
+// checkScript calls the check entrypoint of the job's code at a block.
+func checkScript(job JobCreatedEvent, jobExecutor executor.Executor, code []byte) condition.ScriptFunc {
+    return func(ctx context.Context, block uint64) ([]byte, error) {
+        timestamp, err := blockTimestamp(ctx, block)
+        if (err != nil) {
+            return nil, err
+        }
+        result, err := jobExecutor.Execute(ctx, code, executor.Input{
+            JobID:          job.JobID,
+            TaskID:         job.TaskID,
+            Args:           job.Args,
+            BlockNumber:    block,
+            BlockTimestamp: timestamp,
+            Entrypoint:     executor.EntrypointCheck,
+        })
+        if (err != nil) {
+            return nil, err
+        }
+        return result.Output, nil
+    }
+}
+
+// blockTimestamp is the timestamp of a block, 0 for block 0, which tasks that
+// are not tied to a block run at.
+func blockTimestamp(ctx context.Context, block uint64) (uint64, error) {
+    if (block == 0) {
+        return 0, nil
+    }
+    header, err := chainReader.HeaderByNumber(ctx, new(big.Int).SetUint64(block))
+    if (err != nil) {
+        return 0, fmt.Errorf("reading the timestamp of block %d: %w", block, err)
+    }
+    return header.Time, nil
+}
+
+func setCheckResult(taskResponse *core.TaskResponse, checkResult condition.Result) {
+    taskResponse.CheckBlock = checkResult.Block
+    taskResponse.CheckResultHash = checkResult.Hash()
+}
+
+// respond signs the task response, records it in the ledger and sends it to
+// the aggregator. The signature is recorded before it is sent, so that a task
+// that is resent afterwards gets the same response. It reports whether the
+// response was signed and recorded, and returns the signed execution.
+func respond(job JobCreatedEvent, taskResponse *core.TaskResponse, execution ledger.Execution) (ledger.Execution, bool) {
+    signature, err := signTaskResponse(taskResponse)
+    if (err != nil) {
+        log.Printf("Error signing result of job %d: %v", job.JobID, err)
+        finishFailed(execution, err)
+        return execution, false
+    }
+    execution.Status = ledger.StatusSigned
+    execution.TaskResponse = taskResponse
+    execution.Signature = signature
+    execution.FinishedAt = time.Now()
+    if err := executionLedger.Finish(execution); (err != nil) {
+        log.Printf("Error recording result of job %d, not sending it: %v", job.JobID, err)
+        return execution, false
+    }
+    sendResult(execution)
+    return execution, true
+}
+
+// sendResult sends the signed response of an execution to the aggregator.
+func sendResult(execution ledger.Execution) {
+    if err := sendSignedResultToAggregator(execution.TaskResponse, execution.Signature); (err != nil) {
+        log.Printf("Error sending result of job %d to the aggregator: %v", execution.Key.JobID, err)
+        return
+    }
+    log.Printf("Result of job %d accepted by the aggregator", execution.Key.JobID)
+}
+
+// finishFailed records in the ledger that the execution failed, so that the
+// task may be run again.
+func finishFailed(execution ledger.Execution, err error) {
+    execution.Status = ledger.StatusFailed
+    execution.Error = err.Error()
+    execution.FinishedAt = time.Now()
+    if err := executionLedger.Finish(execution); (err != nil) {
+        log.Printf("Error recording failure of %s: %v", execution.Key, err)
+    }
+}
+
+// upkeepStatus is the status of an upkeep job's task response: succeeded if
+// the job returned a call, skipped if it needs no upkeep and failed if what it
+// returned is not a call. Operators attest to the call through the response's
+// ResultHash, whether or not they submit it, so the status does not depend on
+// the transaction.
+func upkeepStatus(jobID uint32, output []byte) uint8 {
+    _, err := upkeep.ParseCall(output)
+    if (errors.Is(err, upkeep.ErrNoUpkeep)) {
+        log.Printf("Job %d needs no upkeep", jobID)
+        return core.TaskStatusSkipped
+    }
+    if (err != nil) {
+        log.Printf("Error reading upkeep of job %d: %v", jobID, err)
+        return core.TaskStatusFailed
+    }
+    return core.TaskStatusSucceeded
+}
 
-    message := []byte(encodedData)
-    signature := privKey.Sign(message, nil)
+// submitUpkeep submits the call an upkeep job returned, as the operator the
+// task is assigned to. The signed transaction is recorded in the ledger before
+// it is sent, so that a resent task sends it again instead of another one.
+// Once it is included, the operator attests to it, see finishUpkeep.
+func submitUpkeep(ctx context.Context, execution ledger.Execution) {
+    jobID := execution.Key.JobID
+    if (upkeepSubmitter == nil) {
+        log.Printf("Error submitting upkeep of job %d: no ecdsa_private_key_store_path configured", jobID)
+        return
+    }
+    call, err := upkeep.ParseCall(execution.Output)
+    if (err != nil) {
+        log.Printf("Error reading upkeep of job %d: %v", jobID, err)
+        return
+    }
+    receipt, err := upkeepSubmitter.Submit(ctx, call, func(tx *gethtypes.Transaction) error {
+        raw, err := tx.MarshalBinary()
+        if (err != nil) {
+            return err
+        }
+        execution.UpkeepTx = raw
+        return executionLedger.Finish(execution)
+    })
+    finishUpkeep(execution, receipt, err)
+}
 
-    signedData := signature.Serialize()
-    return fmt.Sprintf("%x", signedData)
+// resendUpkeep sends the upkeep transaction recorded for an execution again,
+// in case the keeper stopped before it was sent or included.
+func resendUpkeep(execution ledger.Execution) {
+    if (upkeepSubmitter == nil) {
+        return
+    }
+    tx := new(gethtypes.Transaction)
+    if err := tx.UnmarshalBinary(execution.UpkeepTx); (err != nil) {
+        log.Printf("Error reading the upkeep transaction of %s: %v", execution.Key, err)
+        return
+    }
+    ctx, cancel := context.WithTimeout(context.Background(), upkeepResendTimeout)
+    defer cancel()
+    receipt, err := upkeepSubmitter.Resend(ctx, tx)
+    finishUpkeep(execution, receipt, err)
+}
+
+// finishUpkeep signs the execution's task response again with the hash and
+// status of its included upkeep transaction, and sends it to the aggregator,
+// which records it on chain. The response the quorum signed cannot carry the
+// transaction, see core.TaskResponse.TxHash.
+func finishUpkeep(execution ledger.Execution, receipt *gethtypes.Receipt, err error) {
+    jobID := execution.Key.JobID
+    if (err != nil) {
+        log.Printf("Error submitting upkeep of job %d: %v", jobID, err)
+        return
+    }
+    upkeepResponse := *execution.TaskResponse
+    upkeepResponse.TxHash = receipt.TxHash
+    upkeepResponse.TxStatus = core.UpkeepTxSucceeded
+    if (receipt.Status != gethtypes.ReceiptStatusSuccessful) {
+        upkeepResponse.TxStatus = core.UpkeepTxReverted
+        log.Printf("Upkeep transaction %s of job %d reverted", receipt.TxHash.Hex(), jobID)
+    } else {
+        log.Printf("Upkeep transaction %s of job %d succeeded", receipt.TxHash.Hex(), jobID)
+    }
+    signature, err := signTaskResponse(&upkeepResponse)
+    if (err != nil) {
+        log.Printf("Error signing upkeep of job %d: %v", jobID, err)
+        return
+    }
+    if err := sendSignedResultToAggregator(&upkeepResponse, signature); (err != nil) {
+        log.Printf("Error sending upkeep of job %d to the aggregator: %v", jobID, err)
+        return
+    }
+    log.Printf("Upkeep of job %d accepted by the aggregator", jobID)
 }
 
-func sendSignedResultToAggregator(signedData string, jobID uint32) {
+func newUpkeepSubmitter(nodeConfig keepertypes.NodeConfig, ethClient *ethclient.Client, chainId *big.Int) (*upkeep.Submitter, error) {
+    if (nodeConfig.EcdsaPrivateKeyStorePath == "") {
+        return nil, nil
+    }
+    policy, err := upkeepPolicy()
+    if (err != nil) {
+        return nil, err
+    }
+    signer, address, err := signerv2.SignerFromConfig(signerv2.Config{
+        KeystorePath: nodeConfig.EcdsaPrivateKeyStorePath,
+        Password:     os.Getenv("ECDSA_KEY_PASSWORD"),
+    }, chainId)
+    if (err != nil) {
+        return nil, err
+    }
+    txSigner, err := signer(context.Background(), address)
+    if (err != nil) {
+        return nil, err
+    }
+    log.Printf("Submitting upkeep transactions from %s to %d allowed targets", address.Hex(), len(policy.AllowedTargets))
+    return upkeep.NewSubmitter(ethClient, chainId, txSigner, address, policy), nil
+}
+
+// upkeepPolicy reads UPKEEP_ALLOWED_TARGETS, a comma separated list of
+// contract addresses, and UPKEEP_MAX_VALUE, in wei.
+func upkeepPolicy() (upkeep.Policy, error) {
+    var policy upkeep.Policy
+    for _, target := range strings.Split(os.Getenv("UPKEEP_ALLOWED_TARGETS"), ",") {
+        target = strings.TrimSpace(target)
+        if (target == "") {
+            continue
+        }
+        if (!common.IsHexAddress(target)) {
+            return upkeep.Policy{}, fmt.Errorf("invalid UPKEEP_ALLOWED_TARGETS address %q", target)
+        }
+        policy.AllowedTargets = append(policy.AllowedTargets, common.HexToAddress(target))
+    }
+    maxValue, ok := new(big.Int).SetString(envOrDefault("UPKEEP_MAX_VALUE", "0"), 0)
+    if (!ok || maxValue.Sign() < 0) {
+        return upkeep.Policy{}, fmt.Errorf("invalid UPKEEP_MAX_VALUE %q", os.Getenv("UPKEEP_MAX_VALUE"))
+    }
+    policy.MaxValue = maxValue
+    return policy, nil
+}
+
+func newTaskResponse(job JobCreatedEvent, status uint8, output []byte) *core.TaskResponse {
+    return &core.TaskResponse{
+        Version:         core.TaskResponseVersion,
+        ReferenceTaskId: job.TaskID,
+        JobId:           job.JobID,
+        Status:          status,
+        ResultHash:      crypto.Keccak256Hash(output),
+    }
+}
+
+// signTaskResponse signs the task response digest with the operator's BN254 key,
+// the same way the aggregator computes it with core.GetTaskResponseDigest.
+func signTaskResponse(taskResponse *core.TaskResponse) (*bls.Signature, error) {
+    taskResponseDigest, err := core.GetTaskResponseDigest(taskResponseDomain, taskResponse)
+    if (err != nil) {
+        return nil, err
+    }
+    return blsKeyPair.SignMessage(taskResponseDigest), nil
+}
+
+func sendSignedResultToAggregator(taskResponse *core.TaskResponse, signature *bls.Signature) error {
     // Construct the signed task response
     signedTaskResponse := &aggregator.SignedTaskResponse{
-        JobID:      jobID,
-        SignedData: signedData,
+        TaskResponse: *taskResponse,
+        BlsSignature: *signature,
+        OperatorId:   operatorId,
     }
 
     // Send the signed task response to the aggregator
-    rpcClient.SendSignedTaskResponseToAggregator(signedTaskResponse)
-}
\ No newline at end of file
+    return aggregatorClient.SendSignedTaskResponseToAggregator(context.Background(), signedTaskResponse)
+}
+
+func envOrDefault(key string, fallback string) string {
+    if value := os.Getenv(key); value != "" {
+        return value
+    }
+    return fallback
+}
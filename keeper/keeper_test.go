@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/Layr-Labs/incredible-squaring-avs/keeper/condition"
+	"github.com/Layr-Labs/incredible-squaring-avs/keeper/executor"
+)
+
+// fakeChain serves headers whose timestamp is 12 times their number, and
+// records the blocks contracts are called at.
+type fakeChain struct {
+	head  uint64
+	calls []uint64
+}
+
+func (c *fakeChain) HeaderByNumber(_ context.Context, number *big.Int) (*gethtypes.Header, error) {
+	if number == nil {
+		number = new(big.Int).SetUint64(c.head)
+	}
+	return &gethtypes.Header{Number: number, Time: 12 * number.Uint64()}, nil
+}
+
+func (c *fakeChain) CallContract(_ context.Context, _ ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.calls = append(c.calls, blockNumber.Uint64())
+	return make([]byte, 32), nil
+}
+
+// wasmEthCallModule is the binary encoding of
+//
+//	(module
+//	  (import "keeper" "eth_call" (func (param i32 i32 i32 i32 i32) (result i32)))
+//	  (import "keeper" "set_result" (func (param i32 i32)))
+//	  (memory (export "memory") 1)
+//	  (func (export "run") (result i32)
+//	    (drop (call 0 (i32.const 0) (i32.const 0) (i32.const 0) (i32.const 0) (i32.const 0)))
+//	    (call 1 (i32.const 0) (i32.const 0))
+//	    (i32.const 0)))
+var wasmEthCallModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x01, 0x13, 0x03, 0x60, 0x05, 0x7f, 0x7f, 0x7f,
+	0x7f, 0x7f, 0x01, 0x7f, 0x60, 0x02, 0x7f, 0x7f, 0x00, 0x60, 0x00, 0x01, 0x7f, 0x02, 0x27, 0x02,
+	0x06, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x08, 0x65, 0x74, 0x68, 0x5f, 0x63, 0x61, 0x6c, 0x6c,
+	0x00, 0x00, 0x06, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x0a, 0x73, 0x65, 0x74, 0x5f, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x00, 0x01, 0x03, 0x02, 0x01, 0x02, 0x05, 0x03, 0x01, 0x00, 0x01, 0x07,
+	0x10, 0x02, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00, 0x03, 0x72, 0x75, 0x6e, 0x00,
+	0x02, 0x0a, 0x19, 0x01, 0x17, 0x00, 0x41, 0x00, 0x41, 0x00, 0x41, 0x00, 0x41, 0x00, 0x41, 0x00,
+	0x10, 0x00, 0x1a, 0x41, 0x00, 0x41, 0x00, 0x10, 0x01, 0x41, 0x00, 0x0b,
+}
+
+func TestJobInputResolvesTheBlockOfTheTask(t *testing.T) {
+	chain := &fakeChain{head: 100}
+	chainReader = chain
+	event := map[string]interface{}{"event": map[string]interface{}{"blockNumber": float64(42)}}
+
+	for _, test := range []struct {
+		name        string
+		job         JobCreatedEvent
+		checkResult *condition.Result
+		want        uint64
+	}{
+		{"check", JobCreatedEvent{Args: event, BlockNumber: 40}, &condition.Result{Block: 45}, 45},
+		{"trigger", JobCreatedEvent{Args: event, BlockNumber: 40}, nil, 42},
+		{"task", JobCreatedEvent{BlockNumber: 40}, nil, 40},
+	} {
+		input, err := jobInput(context.Background(), test.job, test.checkResult)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if input.BlockNumber != test.want || input.BlockTimestamp != 12*test.want {
+			t.Errorf("%s: input at block %d time %d, want block %d time %d",
+				test.name, input.BlockNumber, input.BlockTimestamp, test.want, 12*test.want)
+		}
+	}
+}
+
+func TestWasmJobReadsStateAtTheTriggerBlock(t *testing.T) {
+	chain := &fakeChain{head: 100}
+	chainReader = chain
+	job := JobCreatedEvent{
+		Args:        map[string]interface{}{"event": map[string]interface{}{"blockNumber": float64(42)}},
+		BlockNumber: 40,
+	}
+
+	input, err := jobInput(context.Background(), job, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := executor.NewWasmExecutor(executor.DefaultLimits, 0, chain).Execute(context.Background(), wasmEthCallModule, input); err != nil {
+		t.Fatal(err)
+	}
+	if len(chain.calls) != 1 || chain.calls[0] != 42 {
+		t.Errorf("job read state at blocks %v, want the trigger block 42", chain.calls)
+	}
+}
@@ -0,0 +1,247 @@
+// Package ledger records every execution of a task by the keeper: the task as
+// it was received, the job's output, how long it took and the signed task
+// response. A task the keeper already ran is not run again, its signed
+// response is sent again instead, so that a resent task never gets two
+// conflicting signatures from the same operator.
+package ledger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/incredible-squaring-avs/core"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Layout: the top level executions bucket holds one bucket per Key, keyed by
+// the big endian job id, task id and trigger block. Each of them holds the
+// attempts at executing the task, keyed by the big endian attempt number.
+var executionsBucket = []byte("executions")
+
+const openTimeout = 5 * time.Second
+
+// ErrNotStarted is returned when finishing an execution the ledger has no record of.
+var ErrNotStarted = errors.New("execution was not started")
+
+// Key identifies a task. The trigger block is the block of the event the task
+// was created for, 0 if it was not triggered by an event.
+type Key struct {
+	JobID        uint32
+	TaskID       uint32
+	TriggerBlock uint64
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("job %d task %d block %d", k.JobID, k.TaskID, k.TriggerBlock)
+}
+
+// Deduped reports whether repeated executions of the task are deduped. Tasks
+// the task manager did not record on chain all have task id 0, so they have
+// nothing to tell them apart, and none to sign conflicting responses for.
+func (k Key) Deduped() bool {
+	return k.TaskID != 0
+}
+
+type Status string
+
+const (
+	StatusRunning Status = "running"
+	StatusSigned  Status = "signed"
+	StatusFailed  Status = "failed"
+)
+
+// Execution is one attempt at executing a task.
+type Execution struct {
+	Key     Key
+	Attempt uint32
+	Status  Status
+	// Request is the task as the keeper received it.
+	Request  []byte
+	CodeHash string
+	// Output and Logs are what the job's code returned and logged.
+	Output []byte
+	Logs   []string
+	// Error is why the execution failed.
+	Error string
+
+	ReceivedAt time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	// TaskResponse and Signature are set once the execution is signed.
+	TaskResponse *core.TaskResponse
+	Signature    *bls.Signature
+	// UpkeepTx is the signed upkeep transaction the operator submitted for the
+	// task, recorded before it is sent, so that it is sent again rather than
+	// made anew.
+	UpkeepTx []byte
+}
+
+// Ledger is a bbolt backed ledger of executions. It is safe for concurrent use.
+type Ledger struct {
+	db *bolt.DB
+}
+
+// Open opens the ledger at path, creating it if it does not exist. Executions
+// that were still running when the ledger was last closed are marked failed,
+// so that their tasks may be run again.
+func Open(path string) (*Ledger, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open execution ledger %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		executions, err := tx.CreateBucketIfNotExists(executionsBucket)
+		if err != nil {
+			return err
+		}
+		return executions.ForEachBucket(func(k []byte) error {
+			attempts := executions.Bucket(k)
+			attempt, value := attempts.Cursor().Last()
+			if attempt == nil {
+				return nil
+			}
+			var execution Execution
+			if err := decode(value, &execution); err != nil {
+				return err
+			}
+			if execution.Status != StatusRunning {
+				return nil
+			}
+			execution.Status = StatusFailed
+			execution.Error = "the keeper stopped while the task was running"
+			value, err := encode(execution)
+			if err != nil {
+				return err
+			}
+			return attempts.Put(attempt, value)
+		})
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Ledger{db: db}, nil
+}
+
+func (l *Ledger) Close() error {
+	return l.db.Close()
+}
+
+// Begin records a new attempt at executing the task, unless the task is
+// deduped and its last attempt is still running or was signed. It returns the
+// new attempt and true, or the last attempt and false.
+func (l *Ledger) Begin(key Key, request []byte, receivedAt time.Time) (Execution, bool, error) {
+	execution := Execution{Key: key, Status: StatusRunning, Request: request, ReceivedAt: receivedAt}
+	started := true
+	err := l.db.Update(func(tx *bolt.Tx) error {
+		attempts, err := tx.Bucket(executionsBucket).CreateBucketIfNotExists(executionKey(key))
+		if err != nil {
+			return err
+		}
+		if attempt, value := attempts.Cursor().Last(); attempt != nil {
+			var last Execution
+			if err := decode(value, &last); err != nil {
+				return err
+			}
+			if key.Deduped() && last.Status != StatusFailed {
+				execution, started = last, false
+				return nil
+			}
+			execution.Attempt = last.Attempt + 1
+		}
+		value, err := encode(execution)
+		if err != nil {
+			return err
+		}
+		return attempts.Put(attemptKey(execution.Attempt), value)
+	})
+	if err != nil {
+		return Execution{}, false, err
+	}
+	return execution, started, nil
+}
+
+// Finish records the outcome of an execution started with Begin.
+func (l *Ledger) Finish(execution Execution) error {
+	value, err := encode(execution)
+	if err != nil {
+		return err
+	}
+	return l.db.Update(func(tx *bolt.Tx) error {
+		attempts := tx.Bucket(executionsBucket).Bucket(executionKey(execution.Key))
+		if attempts == nil || attempts.Get(attemptKey(execution.Attempt)) == nil {
+			return fmt.Errorf("%w: %s attempt %d", ErrNotStarted, execution.Key, execution.Attempt)
+		}
+		return attempts.Put(attemptKey(execution.Attempt), value)
+	})
+}
+
+// Execution returns an attempt at executing the task as it was last recorded.
+func (l *Ledger) Execution(key Key, attempt uint32) (Execution, error) {
+	var execution Execution
+	err := l.db.View(func(tx *bolt.Tx) error {
+		attempts := tx.Bucket(executionsBucket).Bucket(executionKey(key))
+		if attempts == nil || attempts.Get(attemptKey(attempt)) == nil {
+			return fmt.Errorf("%w: %s attempt %d", ErrNotStarted, key, attempt)
+		}
+		return decode(attempts.Get(attemptKey(attempt)), &execution)
+	})
+	return execution, err
+}
+
+// Executions returns every attempt at executing the tasks of a job, by task,
+// trigger block and attempt.
+func (l *Ledger) Executions(jobID uint32) ([]Execution, error) {
+	var executions []Execution
+	err := l.db.View(func(tx *bolt.Tx) error {
+		root := tx.Bucket(executionsBucket)
+		prefix := binary.BigEndian.AppendUint32(nil, jobID)
+		c := root.Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			err := root.Bucket(k).ForEach(func(_, value []byte) error {
+				var execution Execution
+				if err := decode(value, &execution); err != nil {
+					return err
+				}
+				executions = append(executions, execution)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return executions, err
+}
+
+func executionKey(key Key) []byte {
+	k := binary.BigEndian.AppendUint32(nil, key.JobID)
+	k = binary.BigEndian.AppendUint32(k, key.TaskID)
+	return binary.BigEndian.AppendUint64(k, key.TriggerBlock)
+}
+
+func attemptKey(attempt uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, attempt)
+}
+
+func encode(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decode(data []byte, v any) error {
+	if data == nil {
+		return errors.New("missing value")
+	}
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
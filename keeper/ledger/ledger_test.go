@@ -0,0 +1,96 @@
+package ledger
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/incredible-squaring-avs/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerDedupesTasks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.db")
+	l, err := Open(path)
+	require.NoError(t, err)
+
+	key := Key{JobID: 3, TaskID: 7, TriggerBlock: 100}
+	receivedAt := time.Now().Round(0)
+	execution, started, err := l.Begin(key, []byte(`{"jobID":3}`), receivedAt)
+	require.NoError(t, err)
+	require.True(t, started)
+
+	// a resent task is not run again while it is running...
+	running, started, err := l.Begin(key, []byte(`{"jobID":3}`), time.Now())
+	require.NoError(t, err)
+	assert.False(t, started)
+	assert.Equal(t, StatusRunning, running.Status)
+
+	// ...nor once it is signed, and the signed response is returned
+	keyPair, err := bls.NewKeyPairFromString("12248929636257230549931416853095037629726205319386239410403476017439825112537")
+	require.NoError(t, err)
+	execution.Status = StatusSigned
+	execution.Output = []byte(`"done"`)
+	execution.TaskResponse = &core.TaskResponse{Version: core.TaskResponseVersion, ReferenceTaskId: 7, JobId: 3, Status: core.TaskStatusSucceeded}
+	execution.Signature = keyPair.SignMessage([32]byte{1})
+	execution.FinishedAt = receivedAt.Add(time.Second)
+	require.NoError(t, l.Finish(execution))
+	signed, started, err := l.Begin(key, nil, time.Now())
+	require.NoError(t, err)
+	assert.False(t, started)
+	assert.Equal(t, execution, signed)
+
+	// other trigger blocks of the task are other executions
+	_, started, err = l.Begin(Key{JobID: 3, TaskID: 7, TriggerBlock: 101}, nil, time.Now())
+	require.NoError(t, err)
+	assert.True(t, started)
+
+	err = l.Finish(Execution{Key: Key{JobID: 4, TaskID: 1}})
+	assert.ErrorIs(t, err, ErrNotStarted)
+
+	require.NoError(t, l.Close())
+	l, err = Open(path)
+	require.NoError(t, err)
+	defer l.Close()
+	executions, err := l.Executions(3)
+	require.NoError(t, err)
+	require.Len(t, executions, 2)
+	assert.Equal(t, execution, executions[0])
+	// the keeper stopped while the second one was running
+	assert.Equal(t, StatusFailed, executions[1].Status)
+}
+
+func TestLedgerRetriesFailedAndUnrecordedTasks(t *testing.T) {
+	l, err := Open(filepath.Join(t.TempDir(), "ledger.db"))
+	require.NoError(t, err)
+	defer l.Close()
+
+	key := Key{JobID: 3, TaskID: 7}
+	execution, _, err := l.Begin(key, nil, time.Now())
+	require.NoError(t, err)
+	execution.Status = StatusFailed
+	execution.Error = "boom"
+	require.NoError(t, l.Finish(execution))
+	retry, started, err := l.Begin(key, nil, time.Now())
+	require.NoError(t, err)
+	assert.True(t, started)
+	assert.Equal(t, uint32(1), retry.Attempt)
+	recorded, err := l.Execution(key, 0)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, recorded.Status)
+	assert.Equal(t, "boom", recorded.Error)
+	_, err = l.Execution(key, 2)
+	assert.ErrorIs(t, err, ErrNotStarted)
+
+	// tasks without an id are run every time
+	for i := 0; i < 2; i++ {
+		_, started, err := l.Begin(Key{JobID: 5}, nil, time.Now())
+		require.NoError(t, err)
+		assert.True(t, started)
+	}
+	executions, err := l.Executions(5)
+	require.NoError(t, err)
+	assert.Len(t, executions, 2)
+}
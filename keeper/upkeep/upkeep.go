@@ -0,0 +1,289 @@
+// Package upkeep submits the transactions that upkeep jobs ask for. An upkeep
+// job's code returns the call to make, which the keeper checks against the
+// operator's policy, simulates and then sends from the operator's key.
+//
+// Every operator a task is sent to attests to the call the job returned, and
+// only the operator the task is assigned to submits it, so that a task makes
+// one transaction and the operators' responses agree. That operator then signs
+// the transaction's hash and status, which the aggregator records on chain.
+package upkeep
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrNoUpkeep means the job returned no call, because nothing needs to be done.
+	ErrNoUpkeep = errors.New("job returned no upkeep call")
+	// ErrSimulationFailed means the call reverts, so it was not submitted.
+	ErrSimulationFailed = errors.New("upkeep call fails in simulation")
+	// ErrNotAllowed means the operator's policy does not allow the call.
+	ErrNotAllowed = errors.New("upkeep call is not allowed")
+)
+
+// gasLimitPercent is the gas limit of an upkeep transaction, in percent of
+// its gas estimate.
+const gasLimitPercent = 120
+
+// IsUpkeepJobType reports whether jobs of the type return a call to submit,
+// e.g. "upkeep" or "wasm-upkeep".
+func IsUpkeepJobType(jobType string) bool {
+	return strings.Contains(strings.ToLower(jobType), "upkeep")
+}
+
+// Call is the transaction an upkeep job asks for.
+type Call struct {
+	Target common.Address
+	Data   []byte
+	Value  *big.Int
+}
+
+// callJSON is what an upkeep job returns, e.g.
+// {"target": "0x...", "calldata": "0x...", "value": "0"}. Value is in wei,
+// decimal or 0x-prefixed hex, and may be left out.
+type callJSON struct {
+	Target   string `json:"target"`
+	Calldata string `json:"calldata"`
+	Value    string `json:"value"`
+}
+
+// ParseCall reads the call from a job's output. It returns ErrNoUpkeep if the
+// job returned null or no target.
+func ParseCall(output []byte) (*Call, error) {
+	var raw *callJSON
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, fmt.Errorf("upkeep job result is not a call: %w", err)
+	}
+	if raw == nil || raw.Target == "" {
+		return nil, ErrNoUpkeep
+	}
+	if !common.IsHexAddress(raw.Target) {
+		return nil, fmt.Errorf("upkeep target %q is not an address", raw.Target)
+	}
+	call := &Call{Target: common.HexToAddress(raw.Target), Value: new(big.Int)}
+	if raw.Calldata != "" {
+		data, err := hexutil.Decode(raw.Calldata)
+		if err != nil {
+			return nil, fmt.Errorf("upkeep calldata: %w", err)
+		}
+		call.Data = data
+	}
+	if raw.Value != "" {
+		value, ok := new(big.Int).SetString(raw.Value, 0)
+		if !ok || value.Sign() < 0 {
+			return nil, fmt.Errorf("upkeep value %q is not an amount of wei", raw.Value)
+		}
+		call.Value = value
+	}
+	return call, nil
+}
+
+// Policy is what the operator lets upkeep jobs do with its key. Calls come
+// from job code, which anyone who creates a job controls.
+type Policy struct {
+	// AllowedTargets are the contracts calls may go to. No call is allowed if
+	// there are none.
+	AllowedTargets []common.Address
+	// MaxValue is the most wei a call may send. nil allows none.
+	MaxValue *big.Int
+}
+
+// Check returns ErrNotAllowed if the policy does not allow the call.
+func (p Policy) Check(call *Call) error {
+	allowed := false
+	for _, target := range p.AllowedTargets {
+		if target == call.Target {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s is not an allowed target", ErrNotAllowed, call.Target.Hex())
+	}
+	maxValue := p.MaxValue
+	if maxValue == nil {
+		maxValue = new(big.Int)
+	}
+	if call.Value != nil && call.Value.Cmp(maxValue) > 0 {
+		return fmt.Errorf("%w: it sends %s wei, more than the %s allowed", ErrNotAllowed, call.Value, maxValue)
+	}
+	return nil
+}
+
+// Chain is the chain upkeep calls are simulated against and sent to.
+// *ethclient.Client satisfies it.
+type Chain interface {
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// Submitter simulates the upkeep calls the policy allows and sends the ones
+// that succeed. It signs the transactions itself, so that they can be recorded
+// before they are sent, and sent again rather than made anew.
+type Submitter struct {
+	chain   Chain
+	chainID *big.Int
+	signer  bind.SignerFn
+	// from is the address signer signs for, which the simulation runs as
+	from   common.Address
+	policy Policy
+	// receiptPollInterval is how often the receipt of a sent transaction is polled for.
+	receiptPollInterval time.Duration
+
+	// mu is held from picking a transaction's nonce until it is sent, so that
+	// concurrent upkeeps do not get the same nonce.
+	mu sync.Mutex
+}
+
+func NewSubmitter(chain Chain, chainID *big.Int, signer bind.SignerFn, from common.Address, policy Policy) *Submitter {
+	return &Submitter{
+		chain:               chain,
+		chainID:             chainID,
+		signer:              signer,
+		from:                from,
+		policy:              policy,
+		receiptPollInterval: 2 * time.Second,
+	}
+}
+
+// Submit checks the call against the policy, simulates it against the latest
+// block and, if it succeeds, signs it, passes the signed transaction to record
+// and sends it once record returns, then waits for its receipt. Nothing is
+// sent if record fails. The receipt may still report a revert, if the chain
+// changed in between.
+func (s *Submitter) Submit(ctx context.Context, call *Call, record func(tx *types.Transaction) error) (*types.Receipt, error) {
+	if err := s.policy.Check(call); err != nil {
+		return nil, err
+	}
+	msg := ethereum.CallMsg{From: s.from, To: &call.Target, Data: call.Data, Value: call.Value}
+	if _, err := s.chain.CallContract(ctx, msg, nil); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSimulationFailed, err)
+	}
+
+	tx, err := s.signAndSend(ctx, msg, record)
+	if err != nil {
+		return nil, err
+	}
+	return s.waitForReceipt(ctx, tx)
+}
+
+// Resend sends a transaction Submit recorded again, unless it was already
+// included, and waits for its receipt. The transaction keeps its nonce, so it
+// is included at most once however often it is sent.
+func (s *Submitter) Resend(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	if receipt, err := s.chain.TransactionReceipt(ctx, tx.Hash()); err == nil {
+		return receipt, nil
+	}
+	if err := s.chain.SendTransaction(ctx, tx); err != nil && !strings.Contains(err.Error(), txpool.ErrAlreadyKnown.Error()) {
+		return nil, fmt.Errorf("failed to send upkeep transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	return s.waitForReceipt(ctx, tx)
+}
+
+func (s *Submitter) signAndSend(ctx context.Context, msg ethereum.CallMsg, record func(tx *types.Transaction) error) (*types.Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	gas, err := s.chain.EstimateGas(ctx, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate the gas of the upkeep call: %w", err)
+	}
+	nonce, err := s.chain.PendingNonceAt(ctx, s.from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the nonce of %s: %w", s.from.Hex(), err)
+	}
+	txData, err := s.feeTx(ctx, nonce, gas*gasLimitPercent/100, msg)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.signer(s.from, types.NewTx(txData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the upkeep transaction: %w", err)
+	}
+	if err := record(tx); err != nil {
+		return nil, fmt.Errorf("failed to record upkeep transaction %s, not sending it: %w", tx.Hash().Hex(), err)
+	}
+	if err := s.chain.SendTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to send upkeep transaction to %s: %w", msg.To.Hex(), err)
+	}
+	return tx, nil
+}
+
+// feeTx returns the unsigned transaction for the call, a dynamic fee
+// transaction on chains with a base fee, and a legacy one on chains without,
+// such as chains from before London and some devnets.
+func (s *Submitter) feeTx(ctx context.Context, nonce, gas uint64, msg ethereum.CallMsg) (types.TxData, error) {
+	header, err := s.chain.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the head block: %w", err)
+	}
+	if header.BaseFee == nil {
+		gasPrice, err := s.chain.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the gas price: %w", err)
+		}
+		return &types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: gasPrice,
+			Gas:      gas,
+			To:       msg.To,
+			Value:    msg.Value,
+			Data:     msg.Data,
+		}, nil
+	}
+	tipCap, err := s.chain.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the gas tip: %w", err)
+	}
+	// twice the base fee keeps the transaction includable through a few full blocks
+	feeCap := new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tipCap)
+	return &types.DynamicFeeTx{
+		ChainID:   s.chainID,
+		Nonce:     nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       gas,
+		To:        msg.To,
+		Value:     msg.Value,
+		Data:      msg.Data,
+	}, nil
+}
+
+func (s *Submitter) waitForReceipt(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	ticker := time.NewTicker(s.receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := s.chain.TransactionReceipt(ctx, tx.Hash())
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("failed to get the receipt of upkeep transaction %s: %w", tx.Hash().Hex(), err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("upkeep transaction %s was sent but not included: %w", tx.Hash().Hex(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package upkeep
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestParseCall(t *testing.T) {
+	call, err := ParseCall([]byte(`{"target": "0x00000000000000000000000000000000000000aa", "calldata": "0x01020304", "value": "0x10"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Target != common.HexToAddress("0xaa") || len(call.Data) != 4 || call.Value.Int64() != 16 {
+		t.Errorf("ParseCall = %+v", call)
+	}
+
+	for _, output := range []string{`null`, `{}`, `{"target": ""}`} {
+		if _, err := ParseCall([]byte(output)); !errors.Is(err, ErrNoUpkeep) {
+			t.Errorf("ParseCall(%s) error = %v, want ErrNoUpkeep", output, err)
+		}
+	}
+	for _, output := range []string{`42`, `{"target": "nope"}`, `{"target": "0x00000000000000000000000000000000000000aa", "value": "-1"}`} {
+		if _, err := ParseCall([]byte(output)); err == nil || errors.Is(err, ErrNoUpkeep) {
+			t.Errorf("ParseCall(%s) error = %v, want an invalid call", output, err)
+		}
+	}
+}
+
+type fakeChain struct {
+	simulationErr error
+	// preLondon makes the chain's blocks have no base fee
+	preLondon bool
+	sent      []*types.Transaction
+}
+
+func (f *fakeChain) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return nil, f.simulationErr
+}
+
+func (f *fakeChain) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 50000, nil
+}
+
+func (f *fakeChain) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	if f.preLondon {
+		return &types.Header{Number: big.NewInt(1)}, nil
+	}
+	return &types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(1e9)}, nil
+}
+
+func (f *fakeChain) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return big.NewInt(1e9), nil
+}
+
+func (f *fakeChain) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return big.NewInt(2e9), nil
+}
+
+func (f *fakeChain) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	return uint64(len(f.sent)), nil
+}
+
+func (f *fakeChain) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	for _, sent := range f.sent {
+		if sent.Hash() == tx.Hash() {
+			return txpool.ErrAlreadyKnown
+		}
+	}
+	f.sent = append(f.sent, tx)
+	return nil
+}
+
+func (f *fakeChain) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	for _, sent := range f.sent {
+		if sent.Hash() == txHash {
+			return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: txHash}, nil
+		}
+	}
+	return nil, ethereum.NotFound
+}
+
+func newTestSubmitter(t *testing.T, chain Chain, policy Policy) *Submitter {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(31337))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewSubmitter(chain, big.NewInt(31337), opts.Signer, opts.From, policy)
+}
+
+func recordNothing(*types.Transaction) error { return nil }
+
+func TestSubmitSkipsCallsThatFailInSimulation(t *testing.T) {
+	call := &Call{Target: common.HexToAddress("0xaa"), Data: []byte{1}, Value: big.NewInt(0)}
+	policy := Policy{AllowedTargets: []common.Address{call.Target}}
+
+	chain := &fakeChain{simulationErr: errors.New("execution reverted")}
+	_, err := newTestSubmitter(t, chain, policy).Submit(context.Background(), call, recordNothing)
+	if !errors.Is(err, ErrSimulationFailed) || len(chain.sent) != 0 {
+		t.Fatalf("Submit error = %v with %d sent, want ErrSimulationFailed with none sent", err, len(chain.sent))
+	}
+
+	chain = &fakeChain{}
+	receipt, err := newTestSubmitter(t, chain, policy).Submit(context.Background(), call, recordNothing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain.sent) != 1 || *chain.sent[0].To() != call.Target || receipt.TxHash != chain.sent[0].Hash() {
+		t.Errorf("Submit sent %v, receipt %+v", chain.sent, receipt)
+	}
+}
+
+func TestSubmitRecordsTheTransactionBeforeSendingIt(t *testing.T) {
+	call := &Call{Target: common.HexToAddress("0xaa"), Data: []byte{1}, Value: big.NewInt(0)}
+	chain := &fakeChain{}
+	submitter := newTestSubmitter(t, chain, Policy{AllowedTargets: []common.Address{call.Target}})
+
+	_, err := submitter.Submit(context.Background(), call, func(*types.Transaction) error { return errors.New("disk full") })
+	if err == nil || len(chain.sent) != 0 {
+		t.Fatalf("Submit error = %v with %d sent, want an error with none sent", err, len(chain.sent))
+	}
+
+	var recorded *types.Transaction
+	_, err = submitter.Submit(context.Background(), call, func(tx *types.Transaction) error {
+		if len(chain.sent) != 0 {
+			t.Error("transaction was sent before it was recorded")
+		}
+		recorded = tx
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sending the recorded transaction again does not make another one
+	receipt, err := submitter.Resend(context.Background(), recorded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain.sent) != 1 || receipt.TxHash != recorded.Hash() {
+		t.Errorf("Resend sent %v, receipt %+v, want only %s", chain.sent, receipt, recorded.Hash().Hex())
+	}
+}
+
+func TestSubmitSendsLegacyTransactionsWithoutABaseFee(t *testing.T) {
+	call := &Call{Target: common.HexToAddress("0xaa"), Data: []byte{1}, Value: big.NewInt(0)}
+	for _, preLondon := range []bool{false, true} {
+		chain := &fakeChain{preLondon: preLondon}
+		submitter := newTestSubmitter(t, chain, Policy{AllowedTargets: []common.Address{call.Target}})
+		if _, err := submitter.Submit(context.Background(), call, recordNothing); err != nil {
+			t.Fatal(err)
+		}
+		want := uint8(types.DynamicFeeTxType)
+		if preLondon {
+			want = types.LegacyTxType
+		}
+		if len(chain.sent) != 1 || chain.sent[0].Type() != want {
+			t.Errorf("pre-London %t: sent %v, want one transaction of type %d", preLondon, chain.sent, want)
+		}
+	}
+}
+
+func TestSubmitEnforcesPolicy(t *testing.T) {
+	allowed := common.HexToAddress("0xaa")
+	chain := &fakeChain{}
+	submitter := newTestSubmitter(t, chain, Policy{
+		AllowedTargets: []common.Address{allowed},
+		MaxValue:       big.NewInt(100),
+	})
+	for _, call := range []*Call{
+		{Target: common.HexToAddress("0xbb"), Value: big.NewInt(0)},
+		{Target: allowed, Value: big.NewInt(101)},
+	} {
+		if _, err := submitter.Submit(context.Background(), call, recordNothing); !errors.Is(err, ErrNotAllowed) {
+			t.Errorf("Submit(%+v) error = %v, want ErrNotAllowed", call, err)
+		}
+	}
+	if len(chain.sent) != 0 {
+		t.Errorf("Submit sent %d transactions the policy does not allow", len(chain.sent))
+	}
+	if err := (Policy{}).Check(&Call{Target: allowed, Value: big.NewInt(0)}); !errors.Is(err, ErrNotAllowed) {
+		t.Errorf("empty policy allowed a call: %v", err)
+	}
+	if err := (Policy{AllowedTargets: []common.Address{allowed}}).Check(&Call{Target: allowed, Value: big.NewInt(1)}); !errors.Is(err, ErrNotAllowed) {
+		t.Errorf("policy without a max value allowed a call with value: %v", err)
+	}
+}
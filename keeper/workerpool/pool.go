@@ -0,0 +1,226 @@
+// Package workerpool runs keeper jobs on a bounded number of workers, highest
+// priority first. Every job runs with its own context and timeout, and a job
+// that panics is logged and dropped without taking the keeper down.
+package workerpool
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"log"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrSaturated means every worker is busy and the queue is full. The job
+	// should be submitted again after RetryAfter.
+	ErrSaturated = errors.New("worker pool is saturated")
+	// ErrClosed means the pool is shutting down.
+	ErrClosed = errors.New("worker pool is closed")
+)
+
+const (
+	defaultQueueSize = 64
+	minRetryAfter    = time.Second
+	maxRetryAfter    = time.Minute
+)
+
+type Config struct {
+	// Workers is the number of jobs run at once. It defaults to the number of CPUs.
+	Workers int
+	// QueueSize is the number of jobs that may wait for a worker.
+	QueueSize int
+	// Timeout bounds the jobs that do not set their own. Zero means no bound.
+	Timeout time.Duration
+}
+
+// Job is a unit of work for the pool.
+type Job struct {
+	// Name identifies the job in logs.
+	Name string
+	// Priority orders the queue: higher priorities run first, and jobs of the
+	// same priority in the order they were submitted.
+	Priority int
+	// Timeout bounds the job, Config.Timeout if zero.
+	Timeout time.Duration
+	// Run does the work. ctx is cancelled when the job times out or the pool
+	// shuts down.
+	Run func(ctx context.Context)
+}
+
+type Pool struct {
+	config Config
+	// ctx is the parent of the jobs' contexts, cancelled when a shutdown runs out of time
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	ready   *sync.Cond
+	queue   jobQueue
+	seq     uint64
+	running int
+	closed  bool
+	// avgDuration is a moving average of how long jobs run, for RetryAfter
+	avgDuration time.Duration
+}
+
+// New starts the workers of a pool.
+func New(config Config) *Pool {
+	if config.Workers <= 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaultQueueSize
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{config: config, ctx: ctx, cancel: cancel}
+	p.ready = sync.NewCond(&p.mu)
+	p.wg.Add(config.Workers)
+	for i := 0; i < config.Workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Submit queues the job. It returns ErrSaturated if the queue is full, and
+// ErrClosed if the pool is shutting down.
+func (p *Pool) Submit(job Job) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrClosed
+	}
+	if len(p.queue) >= p.config.QueueSize {
+		return ErrSaturated
+	}
+	p.seq++
+	heap.Push(&p.queue, &queuedJob{job: job, seq: p.seq})
+	p.ready.Signal()
+	return nil
+}
+
+// Stats returns the number of jobs waiting and running.
+func (p *Pool) Stats() (queued, running int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.queue), p.running
+}
+
+// RetryAfter estimates when a worker frees up for a job submitted now, from
+// how long jobs have been running and how many are waiting.
+func (p *Pool) RetryAfter() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	rounds := time.Duration(len(p.queue)/p.config.Workers + 1)
+	return min(max(p.avgDuration*rounds, minRetryAfter), maxRetryAfter)
+}
+
+// Shutdown stops taking jobs and waits for the queued and running ones to
+// finish. If ctx ends first, the jobs are cancelled, and Shutdown returns once
+// they have returned.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	p.closed = true
+	p.ready.Broadcast()
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for {
+		p.mu.Lock()
+		for len(p.queue) == 0 && !p.closed {
+			p.ready.Wait()
+		}
+		if len(p.queue) == 0 {
+			// closed and drained
+			p.mu.Unlock()
+			return
+		}
+		queued := heap.Pop(&p.queue).(*queuedJob)
+		p.running++
+		p.mu.Unlock()
+
+		start := time.Now()
+		p.run(queued.job)
+		duration := time.Since(start)
+
+		p.mu.Lock()
+		p.running--
+		if p.avgDuration == 0 {
+			p.avgDuration = duration
+		} else {
+			p.avgDuration += (duration - p.avgDuration) / 8
+		}
+		p.mu.Unlock()
+	}
+}
+
+func (p *Pool) run(job Job) {
+	timeout := job.Timeout
+	if timeout == 0 {
+		timeout = p.config.Timeout
+	}
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(p.ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(p.ctx)
+	}
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Job %s panicked: %v\n%s", job.Name, r, debug.Stack())
+		}
+	}()
+	job.Run(ctx)
+}
+
+type queuedJob struct {
+	job Job
+	seq uint64
+}
+
+// jobQueue is a heap of the waiting jobs, highest priority first, then oldest first.
+type jobQueue []*queuedJob
+
+func (q jobQueue) Len() int { return len(q) }
+
+func (q jobQueue) Less(i, j int) bool {
+	if q[i].job.Priority != q[j].job.Priority {
+		return q[i].job.Priority > q[j].job.Priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q jobQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *jobQueue) Push(x any) { *q = append(*q, x.(*queuedJob)) }
+
+func (q *jobQueue) Pop() any {
+	old := *q
+	last := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,105 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJobsRunByPriority(t *testing.T) {
+	p := New(Config{Workers: 1, QueueSize: 10})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func(context.Context) {
+		return func(context.Context) {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+	}
+
+	// the first job holds the only worker while the others queue up
+	started := make(chan struct{})
+	p.Submit(Job{Name: "blocker", Run: func(context.Context) { close(started); <-release }})
+	<-started
+	p.Submit(Job{Name: "low", Priority: -1, Run: record("low")})
+	p.Submit(Job{Name: "normal-1", Run: record("normal-1")})
+	p.Submit(Job{Name: "high", Priority: 5, Run: record("high")})
+	p.Submit(Job{Name: "normal-2", Run: record("normal-2")})
+	close(release)
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"high", "normal-1", "normal-2", "low"}
+	if len(order) != len(want) {
+		t.Fatalf("ran %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("ran %v, want %v", order, want)
+		}
+	}
+}
+
+func TestSubmitReportsSaturation(t *testing.T) {
+	p := New(Config{Workers: 1, QueueSize: 1})
+	release := make(chan struct{})
+	started := make(chan struct{})
+	p.Submit(Job{Run: func(context.Context) { close(started); <-release }})
+	<-started
+	if err := p.Submit(Job{Run: func(context.Context) {}}); err != nil {
+		t.Fatalf("queueing a job: %v", err)
+	}
+	if err := p.Submit(Job{Run: func(context.Context) {}}); !errors.Is(err, ErrSaturated) {
+		t.Errorf("Submit to a full queue = %v, want ErrSaturated", err)
+	}
+	if retryAfter := p.RetryAfter(); retryAfter < minRetryAfter || retryAfter > maxRetryAfter {
+		t.Errorf("RetryAfter = %s", retryAfter)
+	}
+	close(release)
+	p.Shutdown(context.Background())
+	if err := p.Submit(Job{Run: func(context.Context) {}}); !errors.Is(err, ErrClosed) {
+		t.Errorf("Submit after shutdown = %v, want ErrClosed", err)
+	}
+}
+
+func TestJobsAreIsolated(t *testing.T) {
+	p := New(Config{Workers: 1})
+	timedOut := make(chan error, 1)
+	p.Submit(Job{Name: "panics", Run: func(context.Context) { panic("boom") }})
+	p.Submit(Job{Name: "slow", Timeout: 10 * time.Millisecond, Run: func(ctx context.Context) {
+		<-ctx.Done()
+		timedOut <- ctx.Err()
+	}})
+	ran := make(chan struct{})
+	p.Submit(Job{Name: "after", Run: func(context.Context) { close(ran) }})
+
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the job after a panic and a timeout did not run")
+	}
+	if err := <-timedOut; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("slow job's context ended with %v, want its deadline", err)
+	}
+	p.Shutdown(context.Background())
+}
+
+func TestShutdownCancelsJobsWhenOutOfTime(t *testing.T) {
+	p := New(Config{Workers: 1})
+	started := make(chan struct{})
+	p.Submit(Job{Run: func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+	}})
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown = %v, want the deadline", err)
+	}
+}
@@ -1,22 +1,98 @@
 package main
 
 import (
+	"crypto/ecdsa"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/urfave/cli/v2"
+	"taskmanager/config"
+	"taskmanager/notify"
 	"taskmanager/taskmanager"
 )
 
 func main() {
 	app := &cli.App{
 		Name:  "task-manager",
-		Usage: "Listen for USDC transfer events and allocate tasks to operators",
+		Usage: "Listen for Keeper job events and allocate tasks to operators",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "config",
+				Required: true,
+				Usage:    "Load configuration from `FILE`",
+			},
+			&cli.StringFlag{
+				Name:     "keeper-network-deployment",
+				Required: true,
+				Usage:    "Load Keeper contract addresses from `FILE`",
+			},
+			&cli.StringFlag{
+				Name:    "ecdsa-private-key",
+				EnvVars: []string{"TASK_MANAGER_ECDSA_PRIVATE_KEY"},
+				Usage:   "Hex private key that creates and assigns the tasks on chain, or empty to not record them",
+			},
+		},
 		Action: func(c *cli.Context) error {
-			clientURL := "ws://localhost:8545"
-			contractAddr := "0x9E545E3C0baAB3E08CdfD552C960A1050f373042"
-
-			tm, err := taskmanager.NewTaskManager(clientURL, contractAddr)
+			cfg, err := config.Load(c.String("config"), c.String("keeper-network-deployment"))
+			if err != nil {
+				return err
+			}
+			if cfg.Environment == "development" {
+				log.SetFlags(log.LstdFlags | log.Lshortfile)
+			}
+			var privateKey *ecdsa.PrivateKey
+			if key := c.String("ecdsa-private-key"); key != "" {
+				privateKey, err = crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+				if err != nil {
+					return fmt.Errorf("invalid ecdsa private key: %w", err)
+				}
+			}
+			notifier, err := notify.New(cfg.Notifications)
+			if err != nil {
+				return err
+			}
+			registry := prometheus.NewRegistry()
+			if addr := cfg.MetricsAddress; addr != "" {
+				go func() {
+					mux := http.NewServeMux()
+					mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+					log.Printf("Serving metrics on %s", addr)
+					if err := http.ListenAndServe(addr, mux); err != nil {
+						log.Printf("Metrics server stopped: %v", err)
+					}
+				}()
+			}
+			tm, err := taskmanager.NewTaskManager(taskmanager.Config{
+				ClientURL:                  cfg.EthWsUrl,
+				HTTPClientURL:              cfg.EthRpcUrl,
+				PollInterval:               cfg.PollInterval,
+				ServiceManagerAddr:         cfg.ServiceManagerAddr.Hex(),
+				JobManagerAddr:             optionalAddress(cfg.JobManagerAddr),
+				TaskManagerAddr:            optionalAddress(cfg.TaskManagerAddr),
+				OperatorStateRetrieverAddr: cfg.OperatorStateRetrieverAddr.Hex(),
+				PrivateKey:                 privateKey,
+				AssignmentPolicy:           cfg.Scheduling.AssignmentPolicy,
+				TaskTimeout:                cfg.Scheduling.TaskTimeout,
+				MaxTaskAttempts:            cfg.Scheduling.MaxTaskAttempts,
+				MaxOperatorFailures:        cfg.Scheduling.MaxOperatorFailures,
+				CursorPath:                 cfg.CursorPath,
+				SchedulesPath:              cfg.SchedulesPath,
+				EventCursorsDir:            cfg.EventCursorsDir,
+				StartBlock:                 cfg.StartBlock,
+				BackfillPageSize:           cfg.BackfillPageSize,
+				OperatorSocketsStartBlock:  cfg.OperatorDiscovery.StartBlock,
+				OperatorSocketsPageSize:    cfg.OperatorDiscovery.PageSize,
+				ConfirmationDepths:         cfg.ConfirmationDepths,
+				Notifier:                   notifier,
+				Registry:                   registry,
+			})
 			if err != nil {
 				return err
 			}
@@ -32,8 +108,9 @@ func main() {
 	}
 }
 
-
-
-
-
-
+func optionalAddress(addr common.Address) string {
+	if addr == (common.Address{}) {
+		return ""
+	}
+	return addr.Hex()
+}
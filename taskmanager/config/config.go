@@ -0,0 +1,219 @@
+// Package config reads the task manager's settings from its yaml config file
+// (see config-files/task-manager.yaml) and the contract addresses from the
+// deployment file written by the deployment script.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+
+	"taskmanager/notify"
+	"taskmanager/taskmanager"
+)
+
+// Config is the validated content of the config and deployment files.
+type Config struct {
+	Environment string
+	EthRpcUrl   string
+	EthWsUrl    string
+
+	ServiceManagerAddr         common.Address
+	OperatorStateRetrieverAddr common.Address
+	// JobManagerAddr and TaskManagerAddr are zero if the deployment file does not
+	// list them, in which case they are read from the service manager.
+	JobManagerAddr  common.Address
+	TaskManagerAddr common.Address
+
+	ConfirmationDepths map[uint64]uint64
+	CursorPath         string
+	SchedulesPath      string
+	EventCursorsDir    string
+	StartBlock         uint64
+	BackfillPageSize   uint64
+	PollInterval       time.Duration
+	MetricsAddress     string
+
+	Scheduling        Scheduling
+	OperatorDiscovery OperatorDiscovery
+	Notifications     notify.Config
+}
+
+// Scheduling is how tasks are given to operators.
+type Scheduling struct {
+	AssignmentPolicy    string        `yaml:"assignment_policy"`
+	TaskTimeout         time.Duration `yaml:"task_timeout"`
+	MaxTaskAttempts     int           `yaml:"max_task_attempts"`
+	MaxOperatorFailures int           `yaml:"max_operator_failures"`
+}
+
+// OperatorDiscovery is where the operators' sockets are read from.
+type OperatorDiscovery struct {
+	// StartBlock is the block socket updates are read from, at or before the
+	// deployment of the registry coordinator. It defaults to the start block.
+	StartBlock uint64 `yaml:"start_block"`
+	// PageSize is the number of blocks fetched per eth_getLogs call. It defaults to the backfill page size.
+	PageSize uint64 `yaml:"page_size"`
+}
+
+// These are read from the config file
+type ConfigRaw struct {
+	Environment        string            `yaml:"environment"`
+	EthRpcUrl          string            `yaml:"eth_rpc_url"`
+	EthWsUrl           string            `yaml:"eth_ws_url"`
+	ConfirmationDepths map[uint64]uint64 `yaml:"confirmation_depths"`
+	CursorFile         string            `yaml:"cursor_file"`
+	SchedulesFile      string            `yaml:"schedules_file"`
+	EventCursorsDir    string            `yaml:"event_cursors_dir"`
+	StartBlock         uint64            `yaml:"start_block"`
+	BackfillPageSize   uint64            `yaml:"backfill_page_size"`
+	PollInterval       time.Duration     `yaml:"poll_interval"`
+	MetricsAddress     string            `yaml:"metrics_address"`
+	Scheduling         Scheduling        `yaml:"scheduling"`
+	OperatorDiscovery  OperatorDiscovery `yaml:"operator_discovery"`
+	Notifications      notify.Config     `yaml:"notifications"`
+}
+
+// These are read from the deployment file
+type DeploymentRaw struct {
+	Addresses ContractsRaw `json:"addresses"`
+}
+
+type ContractsRaw struct {
+	ServiceManagerAddr         string `json:"keeperNetworkServiceManager"`
+	JobManagerAddr             string `json:"keeperNetworkJobManager"`
+	TaskManagerAddr            string `json:"keeperNetworkTaskManager"`
+	OperatorStateRetrieverAddr string `json:"operatorStateRetriever"`
+}
+
+// Load reads and validates the config and deployment files. The error lists every invalid setting.
+func Load(configPath, deploymentPath string) (*Config, error) {
+	var configRaw ConfigRaw
+	if err := readYaml(configPath, &configRaw); err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+	var deploymentRaw DeploymentRaw
+	if err := readJson(deploymentPath, &deploymentRaw); err != nil {
+		return nil, fmt.Errorf("failed to read deployment file %s: %w", deploymentPath, err)
+	}
+
+	var errs []error
+	address := func(name, value string, required bool) common.Address {
+		switch {
+		case value == "" && required:
+			errs = append(errs, fmt.Errorf("deployment file: addresses.%s is required", name))
+		case value != "" && !common.IsHexAddress(value):
+			errs = append(errs, fmt.Errorf("deployment file: addresses.%s: %q is not an address", name, value))
+		}
+		return common.HexToAddress(value)
+	}
+	c := &Config{
+		Environment:                configRaw.Environment,
+		EthRpcUrl:                  configRaw.EthRpcUrl,
+		EthWsUrl:                   configRaw.EthWsUrl,
+		ServiceManagerAddr:         address("keeperNetworkServiceManager", deploymentRaw.Addresses.ServiceManagerAddr, true),
+		OperatorStateRetrieverAddr: address("operatorStateRetriever", deploymentRaw.Addresses.OperatorStateRetrieverAddr, true),
+		JobManagerAddr:             address("keeperNetworkJobManager", deploymentRaw.Addresses.JobManagerAddr, false),
+		TaskManagerAddr:            address("keeperNetworkTaskManager", deploymentRaw.Addresses.TaskManagerAddr, false),
+		ConfirmationDepths:         configRaw.ConfirmationDepths,
+		CursorPath:                 configRaw.CursorFile,
+		SchedulesPath:              configRaw.SchedulesFile,
+		EventCursorsDir:            configRaw.EventCursorsDir,
+		StartBlock:                 configRaw.StartBlock,
+		BackfillPageSize:           configRaw.BackfillPageSize,
+		PollInterval:               configRaw.PollInterval,
+		MetricsAddress:             configRaw.MetricsAddress,
+		Scheduling:                 configRaw.Scheduling,
+		OperatorDiscovery:          configRaw.OperatorDiscovery,
+		Notifications:              configRaw.Notifications,
+	}
+	if c.OperatorDiscovery.StartBlock == 0 {
+		c.OperatorDiscovery.StartBlock = c.StartBlock
+	}
+	if c.OperatorDiscovery.PageSize == 0 {
+		c.OperatorDiscovery.PageSize = c.BackfillPageSize
+	}
+	if err := errors.Join(append(errs, c.validate()...)...); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return c, nil
+}
+
+func (c *Config) validate() []error {
+	var errs []error
+	if c.Environment != "production" && c.Environment != "development" {
+		errs = append(errs, fmt.Errorf("environment: %q is not production or development", c.Environment))
+	}
+	if err := validateUrl(c.EthRpcUrl, "http", "https"); err != nil {
+		errs = append(errs, fmt.Errorf("eth_rpc_url: %w", err))
+	}
+	if err := validateUrl(c.EthWsUrl, "ws", "wss"); err != nil {
+		errs = append(errs, fmt.Errorf("eth_ws_url: %w", err))
+	}
+	if c.CursorPath == "" {
+		errs = append(errs, errors.New("cursor_file is required"))
+	}
+	if c.SchedulesPath == "" {
+		errs = append(errs, errors.New("schedules_file is required"))
+	}
+	if c.PollInterval < 0 {
+		errs = append(errs, fmt.Errorf("poll_interval: %s is negative", c.PollInterval))
+	}
+	if c.Scheduling.AssignmentPolicy != "" {
+		if _, err := taskmanager.NewAssignmentPolicy(c.Scheduling.AssignmentPolicy); err != nil {
+			errs = append(errs, fmt.Errorf("scheduling.assignment_policy: %w", err))
+		}
+	}
+	if c.Scheduling.TaskTimeout < 0 {
+		errs = append(errs, fmt.Errorf("scheduling.task_timeout: %s is negative", c.Scheduling.TaskTimeout))
+	}
+	if c.Scheduling.MaxTaskAttempts < 0 {
+		errs = append(errs, fmt.Errorf("scheduling.max_task_attempts: %d is negative", c.Scheduling.MaxTaskAttempts))
+	}
+	if c.Scheduling.MaxOperatorFailures < 0 {
+		errs = append(errs, fmt.Errorf("scheduling.max_operator_failures: %d is negative", c.Scheduling.MaxOperatorFailures))
+	}
+	return append(errs, c.Notifications.Validate()...)
+}
+
+func validateUrl(value string, schemes ...string) error {
+	if value == "" {
+		return errors.New("required")
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q is not a %s url", value, schemes[0])
+}
+
+// readYaml fails on settings that are not known, so that typos do not go unnoticed.
+func readYaml(path string, out interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	return decoder.Decode(out)
+}
+
+func readJson(path string, out interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
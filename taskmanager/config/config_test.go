@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testDeploymentFile = "../../contracts/script/output/31337/keeper_network_avs_deployment_output.json"
+
+func TestLoadRepoConfig(t *testing.T) {
+	c, err := Load("../../config-files/task-manager.yaml", testDeploymentFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.EthWsUrl != "ws://localhost:8545" || c.Scheduling.TaskTimeout != 5*time.Minute || c.ConfirmationDepths[1] != 12 {
+		t.Errorf("unexpected config %+v", c)
+	}
+	if c.ServiceManagerAddr.Hex() != "0x84eA74d481Ee0A5332c457a4d796187F6Ba67fEB" {
+		t.Errorf("unexpected service manager %s", c.ServiceManagerAddr.Hex())
+	}
+}
+
+func TestLoadReportsEveryInvalidSetting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "task-manager.yaml")
+	err := os.WriteFile(path, []byte(`
+environment: production
+eth_rpc_url: localhost:8545
+eth_ws_url: ws://localhost:8545
+cursor_file: cursor.json
+schedules_file: schedules.json
+scheduling:
+  assignment_policy: fastest
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Load(path, testDeploymentFile)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, setting := range []string{"eth_rpc_url", "scheduling.assignment_policy"} {
+		if !strings.Contains(err.Error(), setting) {
+			t.Errorf("expected the error to mention %s, got: %v", setting, err)
+		}
+	}
+}
+
+func TestLoadRejectsUnknownSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "task-manager.yaml")
+	if err := os.WriteFile(path, []byte("eth_rpc_urll: http://localhost:8545\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path, testDeploymentFile); err == nil || !strings.Contains(err.Error(), "eth_rpc_urll") {
+		t.Errorf("expected an error about the unknown setting, got %v", err)
+	}
+}
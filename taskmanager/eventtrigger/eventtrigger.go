@@ -0,0 +1,241 @@
+// Package eventtrigger reads the contract event that triggers a job, and
+// decodes the logs of that event into the input of the job.
+//
+// A job declares its event in its description, with the address of the
+// contract and the event's signature, e.g.
+//
+//	event: 0x5FbDB2315678afecb367f032d93F642f64180aa3 Swap(address indexed sender, uint256 amount0In, uint256 amount1In, address indexed to)
+//	event_topics: * 0x000000000000000000000000a0ee7a142d267c1f36714e4a8f75612f20a79720
+//
+// event_topics is optional and filters on the indexed arguments in order: each
+// one is "*" for any value, or one or more 32 byte topics joined by "|".
+package eventtrigger
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var ErrOtherEvent = errors.New("log is not the trigger's event")
+
+// Trigger is the event a job runs on.
+type Trigger struct {
+	Address common.Address
+	Event   abi.Event
+	// Topics filters on the indexed arguments of the event: Topics[i] lists the
+	// values allowed for the i-th indexed argument, any value if it is empty.
+	Topics [][]common.Hash
+}
+
+// Parse reads the trigger from a job's description. It returns nil if the job
+// declares no event.
+func Parse(description string) (*Trigger, error) {
+	var event, topics string
+	for _, line := range strings.Split(description, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "event":
+			event = strings.TrimSpace(value)
+		case "event_topics":
+			topics = strings.TrimSpace(value)
+		}
+	}
+	if event == "" {
+		if topics != "" {
+			return nil, errors.New("event_topics is set but the job declares no event")
+		}
+		return nil, nil
+	}
+
+	address, fragment, _ := strings.Cut(event, " ")
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("event contract %q is not an address", address)
+	}
+	abiEvent, err := ParseEvent(fragment)
+	if err != nil {
+		return nil, err
+	}
+	t := &Trigger{Address: common.HexToAddress(address), Event: abiEvent}
+
+	indexed := 0
+	for _, input := range abiEvent.Inputs {
+		if input.Indexed {
+			indexed++
+		}
+	}
+	for i, field := range strings.Fields(topics) {
+		if i >= indexed {
+			return nil, fmt.Errorf("event_topics has %d filters, but %s has %d indexed arguments", len(strings.Fields(topics)), abiEvent.Name, indexed)
+		}
+		var values []common.Hash
+		if field != "*" {
+			for _, value := range strings.Split(field, "|") {
+				topic, err := hexutil.Decode(value)
+				if err != nil || len(topic) != common.HashLength {
+					return nil, fmt.Errorf("event topic %q is not 32 bytes of hex", value)
+				}
+				values = append(values, common.BytesToHash(topic))
+			}
+		}
+		t.Topics = append(t.Topics, values)
+	}
+	return t, nil
+}
+
+// ParseEvent parses an event signature such as
+// "Transfer(address indexed from, address indexed to, uint256 value)". The
+// "event" keyword and the argument names may be left out. Tuple arguments are
+// not supported.
+func ParseEvent(fragment string) (abi.Event, error) {
+	fragment = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(fragment), "event "))
+	name, rest, ok := strings.Cut(fragment, "(")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || !strings.HasSuffix(rest, ")") {
+		return abi.Event{}, fmt.Errorf("invalid event signature %q, want Name(type [indexed] [name], ...)", fragment)
+	}
+	rest = strings.TrimSuffix(rest, ")")
+	if strings.ContainsAny(rest, "()") {
+		return abi.Event{}, fmt.Errorf("event %s has a tuple argument, which is not supported", name)
+	}
+
+	var inputs abi.Arguments
+	if strings.TrimSpace(rest) != "" {
+		for i, arg := range strings.Split(rest, ",") {
+			fields := strings.Fields(arg)
+			if len(fields) == 0 {
+				return abi.Event{}, fmt.Errorf("event %s has an empty argument", name)
+			}
+			typ, err := abi.NewType(fields[0], "", nil)
+			if err != nil {
+				return abi.Event{}, fmt.Errorf("event %s argument %d: %w", name, i, err)
+			}
+			input := abi.Argument{Name: fmt.Sprintf("arg%d", i), Type: typ}
+			fields = fields[1:]
+			if len(fields) > 0 && fields[0] == "indexed" {
+				input.Indexed = true
+				fields = fields[1:]
+			}
+			switch len(fields) {
+			case 0:
+			case 1:
+				input.Name = fields[0]
+			default:
+				return abi.Event{}, fmt.Errorf("event %s argument %q is not \"type [indexed] [name]\"", name, strings.TrimSpace(arg))
+			}
+			inputs = append(inputs, input)
+		}
+	}
+	return abi.NewEvent(name, name, false, inputs), nil
+}
+
+// Query is the filter for the trigger's logs.
+func (t *Trigger) Query() ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{t.Address},
+		Topics:    append([][]common.Hash{{t.Event.ID}}, t.Topics...),
+	}
+}
+
+// Matches reports whether the log passes the trigger's filter, for logs
+// fetched with a query that matches other events as well.
+func (t *Trigger) Matches(vLog types.Log) bool {
+	if vLog.Address != t.Address || len(vLog.Topics) == 0 || vLog.Topics[0] != t.Event.ID {
+		return false
+	}
+	for i, allowed := range t.Topics {
+		if len(allowed) == 0 {
+			continue
+		}
+		if i+1 >= len(vLog.Topics) || !slices.Contains(allowed, vLog.Topics[i+1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Event is a decoded log of the trigger's event, as the job gets it.
+type Event struct {
+	Name            string         `json:"name"`
+	Address         common.Address `json:"address"`
+	BlockNumber     uint64         `json:"blockNumber"`
+	BlockHash       common.Hash    `json:"blockHash"`
+	TransactionHash common.Hash    `json:"transactionHash"`
+	LogIndex        uint           `json:"logIndex"`
+	// Args are the event's arguments by name. Integers wider than 32 bits are
+	// decimal strings, and byte arrays hex strings, so that they survive being
+	// passed to a script as JSON. Indexed arguments of dynamic types are the
+	// hash of their value.
+	Args map[string]interface{} `json:"args"`
+}
+
+// Decode decodes a log of the trigger's event.
+func (t *Trigger) Decode(vLog types.Log) (*Event, error) {
+	if vLog.Address != t.Address || len(vLog.Topics) == 0 || vLog.Topics[0] != t.Event.ID {
+		return nil, ErrOtherEvent
+	}
+	args := make(map[string]interface{})
+	if err := t.Event.Inputs.NonIndexed().UnpackIntoMap(args, vLog.Data); err != nil {
+		return nil, fmt.Errorf("malformed %s log in tx %s: %w", t.Event.Name, vLog.TxHash.Hex(), err)
+	}
+	var indexed abi.Arguments
+	for _, input := range t.Event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, vLog.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("malformed %s log in tx %s: %w", t.Event.Name, vLog.TxHash.Hex(), err)
+	}
+	for name, value := range args {
+		args[name] = jsonValue(reflect.ValueOf(value))
+	}
+	return &Event{
+		Name:            t.Event.Name,
+		Address:         vLog.Address,
+		BlockNumber:     vLog.BlockNumber,
+		BlockHash:       vLog.BlockHash,
+		TransactionHash: vLog.TxHash,
+		LogIndex:        vLog.Index,
+		Args:            args,
+	}, nil
+}
+
+var bigIntType = reflect.TypeOf((*big.Int)(nil))
+
+// jsonValue converts a decoded argument into a value that keeps its meaning
+// when it is encoded as JSON.
+func jsonValue(v reflect.Value) interface{} {
+	switch {
+	case v.Type() == bigIntType:
+		return v.Interface().(*big.Int).String()
+	case v.Type() == reflect.TypeOf(common.Address{}):
+		return v.Interface().(common.Address).Hex()
+	case v.Kind() == reflect.Int64 || v.Kind() == reflect.Uint64:
+		return fmt.Sprint(v.Interface())
+	case (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() == reflect.Uint8:
+		b := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(b), v)
+		return hexutil.Encode(b)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		values := make([]interface{}, v.Len())
+		for i := range values {
+			values[i] = jsonValue(v.Index(i))
+		}
+		return values
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,121 @@
+package eventtrigger
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const swapDescription = `Rebalance the pool
+event: 0x00000000000000000000000000000000000000aa Swap(address indexed sender, uint256 amount0In, bytes32 ref, address indexed to)
+event_topics: * 0x00000000000000000000000000000000000000000000000000000000000000bb|0x00000000000000000000000000000000000000000000000000000000000000cc`
+
+func TestParse(t *testing.T) {
+	trigger, err := Parse(swapDescription)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trigger.Address != common.HexToAddress("0xaa") || trigger.Event.Name != "Swap" {
+		t.Errorf("Parse = %+v", trigger)
+	}
+	if want := crypto.Keccak256Hash([]byte("Swap(address,uint256,bytes32,address)")); trigger.Event.ID != want {
+		t.Errorf("event id = %s, want %s", trigger.Event.ID.Hex(), want.Hex())
+	}
+	query := trigger.Query()
+	if len(query.Topics) != 3 || len(query.Topics[1]) != 0 || len(query.Topics[2]) != 2 {
+		t.Errorf("query topics = %v, want the event id, any sender and two recipients", query.Topics)
+	}
+
+	if trigger, err := Parse("schedule: every 5m"); trigger != nil || err != nil {
+		t.Errorf("Parse without an event = %+v, %v", trigger, err)
+	}
+	for _, description := range []string{
+		"event: pool Swap(address indexed sender)",
+		"event: 0x00000000000000000000000000000000000000aa Swap(address indexed sender",
+		"event: 0x00000000000000000000000000000000000000aa Swap(money amount)",
+		"event: 0x00000000000000000000000000000000000000aa Swap((uint256,uint256) amounts)",
+		"event: 0x00000000000000000000000000000000000000aa Swap(address indexed sender)\nevent_topics: * *",
+		"event: 0x00000000000000000000000000000000000000aa Swap(address indexed sender)\nevent_topics: 0x01",
+		"event_topics: *",
+	} {
+		if _, err := Parse(description); err == nil {
+			t.Errorf("Parse(%q) returned no error", description)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	trigger, err := Parse(swapDescription)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amount, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	data, err := trigger.Event.Inputs.NonIndexed().Pack(amount, [32]byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vLog := types.Log{
+		Address:     common.HexToAddress("0xaa"),
+		Topics:      []common.Hash{trigger.Event.ID, common.HexToHash("0x11"), common.HexToHash("0xbb")},
+		Data:        data,
+		BlockNumber: 42,
+		TxHash:      common.HexToHash("0x99"),
+		Index:       3,
+	}
+
+	event, err := trigger.Decode(vLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(event.Args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"amount0In":"123456789012345678901234567890",` +
+		`"ref":"0x0100000000000000000000000000000000000000000000000000000000000000",` +
+		`"sender":"0x0000000000000000000000000000000000000011",` +
+		`"to":"0x00000000000000000000000000000000000000bb"}`
+	if string(encoded) != want {
+		t.Errorf("args = %s, want %s", encoded, want)
+	}
+	if event.Name != "Swap" || event.BlockNumber != 42 || event.LogIndex != 3 {
+		t.Errorf("Decode = %+v", event)
+	}
+
+	vLog.Topics[0] = common.HexToHash("0x01")
+	if _, err := trigger.Decode(vLog); !errors.Is(err, ErrOtherEvent) {
+		t.Errorf("Decode of another event error = %v, want ErrOtherEvent", err)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	trigger, err := Parse(swapDescription)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := func(address string, topics ...common.Hash) types.Log {
+		return types.Log{Address: common.HexToAddress(address), Topics: topics}
+	}
+	sender, to := common.HexToHash("0x01"), common.HexToHash("0xcc")
+	for _, test := range []struct {
+		log  types.Log
+		want bool
+	}{
+		{log("0xaa", trigger.Event.ID, sender, to), true},
+		{log("0xaa", trigger.Event.ID, sender, common.HexToHash("0xbb")), true},
+		{log("0xbb", trigger.Event.ID, sender, to), false},
+		{log("0xaa", crypto.Keccak256Hash([]byte("Sync(uint112,uint112)")), sender, to), false},
+		{log("0xaa", trigger.Event.ID, sender, common.HexToHash("0xdd")), false},
+		{log("0xaa", trigger.Event.ID, sender), false},
+		{log("0xaa"), false},
+	} {
+		if got := trigger.Matches(test.log); got != test.want {
+			t.Errorf("Matches(%v) = %t, want %t", test.log.Topics, got, test.want)
+		}
+	}
+}
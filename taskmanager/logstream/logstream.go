@@ -0,0 +1,358 @@
+// Package logstream follows the logs of a filter and the heads of the chain
+// over websocket subscriptions, and polls for them over http while it cannot
+// subscribe, so that a node that drops its websocket does not stop it.
+//
+// It only depends on go-ethereum, so that the task manager, a module of its
+// own, can have a copy of it (see taskmanager/logstream).
+package logstream
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// transports a stream follows the chain with
+const (
+	TransportWebsocket = "websocket"
+	TransportPolling   = "polling"
+)
+
+const (
+	DefaultPollInterval = 5 * time.Second
+	// DefaultPageSize is the number of blocks fetched per eth_getLogs call while catching up.
+	DefaultPageSize = 2000
+	initialBackoff  = time.Second
+	maxBackoff      = time.Minute
+	// delivered logs are remembered for this many blocks, to drop the copies a catch up fetches again
+	dedupeWindow = 256
+)
+
+// Latest starts a stream at the head it first gets, for the logs emitted from then on.
+const Latest uint64 = math.MaxUint64
+
+// Client is the part of ethclient.Client a stream uses.
+type Client interface {
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// Metrics shows the health of a stream.
+type Metrics interface {
+	// Transport is called with TransportWebsocket or TransportPolling when the stream switches to it.
+	Transport(transport string)
+	// SubscriptionFailed is called when subscribing over websocket failed or a subscription broke.
+	SubscriptionFailed()
+	// SubscriptionReconnected is called when the subscriptions that failed are back over websocket.
+	SubscriptionReconnected()
+	// LogsFetched counts the logs fetched with eth_getLogs, while polling or catching up.
+	LogsFetched(count int)
+}
+
+// Logger logs what happens to a stream's subscriptions.
+type Logger interface {
+	Info(msg string, tags ...any)
+	Warn(msg string, tags ...any)
+}
+
+// HeadTracker tells whether a head replaced blocks, like reorg.Detector.
+type HeadTracker interface {
+	AddHead(ctx context.Context, head *types.Header) (forkPoint uint64, reorged bool, err error)
+}
+
+type logKey struct {
+	blockHash common.Hash
+	index     uint
+}
+
+// Stream delivers the logs matching Query from a given block on, and the new heads of the
+// chain. It follows the chain over websocket subscriptions. When they fail, it polls the node
+// over http every PollInterval, and subscribes again after a backoff, which doubles while
+// subscribing keeps failing. Whenever it subscribes or polls, it fetches the logs of the blocks
+// it has not seen yet, so no log is missed, and it drops the logs it already delivered.
+//
+// Logs removed by a reorg come from the websocket subscription as Removed logs. While polling,
+// the stream cannot see them: either Heads is set, and the stream fetches the logs of the new
+// chain from the fork point on, or OnHead tracks the reorgs and calls Rewind.
+type Stream struct {
+	Query ethereum.FilterQuery
+	// OnLog gets the logs, in chain order but for the Removed ones. If it is nil, no logs are followed.
+	OnLog func(ctx context.Context, vLog types.Log)
+	// OnHead gets the new heads. Heads can be skipped while polling. If it is nil, no heads are followed.
+	OnHead func(ctx context.Context, head *types.Header)
+	// WSClient subscribes. If it is nil, it is dialed with Dial, and if Dial is nil too the stream only polls.
+	WSClient   Client
+	Dial       func(ctx context.Context) (Client, error)
+	HTTPClient Client
+	// Heads, if set, is given the polled heads, see above.
+	Heads        HeadTracker
+	PageSize     uint64
+	PollInterval time.Duration
+	// Metrics and Logger can be nil.
+	Metrics Metrics
+	Logger  Logger
+
+	// next is the first block whose logs the stream has not fetched yet
+	next uint64
+	// rewound is set when next moved back, for the logs from there to be fetched again
+	rewound   bool
+	delivered map[logKey]uint64
+	// the highest block a log was delivered from
+	lastBlock  uint64
+	lastHead   common.Hash
+	subscribed bool
+}
+
+// Run delivers the logs from block from on, or from Latest, until ctx is done.
+func (s *Stream) Run(ctx context.Context, from uint64) {
+	s.init(from)
+	backoff := initialBackoff
+	for ctx.Err() == nil {
+		if s.WSClient != nil || s.Dial != nil {
+			subscribed, err := s.follow(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if subscribed {
+				backoff = initialBackoff
+			}
+			s.Logger.Warn("Websocket subscriptions failed, polling over http", "retryIn", backoff, "err", err)
+			s.Metrics.SubscriptionFailed()
+		}
+		s.Metrics.Transport(TransportPolling)
+		s.poll(ctx, backoff)
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+func (s *Stream) init(from uint64) {
+	s.next = from
+	s.delivered = make(map[logKey]uint64)
+	if s.PageSize == 0 {
+		s.PageSize = DefaultPageSize
+	}
+	if s.PollInterval == 0 {
+		s.PollInterval = DefaultPollInterval
+	}
+	if s.Metrics == nil {
+		s.Metrics = noopMetrics{}
+	}
+	if s.Logger == nil {
+		s.Logger = noopLogger{}
+	}
+}
+
+// Rewind moves the stream back to block, for the logs from there to be fetched again. It is
+// meant to be called from OnHead or OnLog.
+func (s *Stream) Rewind(block uint64) {
+	if block < s.next {
+		s.next = block
+		s.rewound = true
+	}
+}
+
+// follow subscribes over websocket, and delivers what the subscriptions send until one fails.
+// It reports whether subscribing succeeded.
+func (s *Stream) follow(ctx context.Context) (bool, error) {
+	if s.WSClient == nil {
+		// the client reconnects by itself once it has connected
+		client, err := s.Dial(ctx)
+		if err != nil {
+			return false, err
+		}
+		s.WSClient = client
+	}
+	// a nil channel is never ready, for what is not followed
+	var logs chan types.Log
+	var logErrs <-chan error
+	if s.OnLog != nil {
+		logs = make(chan types.Log)
+		sub, err := s.WSClient.SubscribeFilterLogs(ctx, s.Query, logs)
+		if err != nil {
+			return false, err
+		}
+		defer sub.Unsubscribe()
+		logErrs = sub.Err()
+	}
+	var heads chan *types.Header
+	var headErrs <-chan error
+	if s.OnHead != nil {
+		heads = make(chan *types.Header)
+		sub, err := s.WSClient.SubscribeNewHead(ctx, heads)
+		if err != nil {
+			return false, err
+		}
+		defer sub.Unsubscribe()
+		headErrs = sub.Err()
+	}
+
+	if s.subscribed {
+		s.Logger.Info("Websocket subscriptions are back, fetching the logs since", "block", s.next)
+		s.Metrics.SubscriptionReconnected()
+	}
+	s.subscribed = true
+	s.Metrics.Transport(TransportWebsocket)
+	// the subscriptions only send the logs of new blocks
+	if err := s.catchUp(ctx); err != nil {
+		return true, err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		case err := <-logErrs:
+			return true, err
+		case err := <-headErrs:
+			return true, err
+		case vLog := <-logs:
+			s.deliver(ctx, vLog)
+			if err := s.catchUpIfRewound(ctx); err != nil {
+				return true, err
+			}
+			if !vLog.Removed {
+				s.next = max(s.next, vLog.BlockNumber)
+			}
+		case head := <-heads:
+			s.deliverHead(ctx, head)
+			if err := s.catchUpIfRewound(ctx); err != nil {
+				return true, err
+			}
+		}
+	}
+}
+
+// catchUpIfRewound fetches the logs from where the stream was rewound to, which
+// the subscription does not send again.
+func (s *Stream) catchUpIfRewound(ctx context.Context) error {
+	if !s.rewound {
+		return nil
+	}
+	return s.catchUp(ctx)
+}
+
+// poll catches up every PollInterval for duration d, or until ctx is done if the stream cannot subscribe.
+func (s *Stream) poll(ctx context.Context, d time.Duration) {
+	var done <-chan time.Time
+	if s.WSClient != nil || s.Dial != nil {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		done = timer.C
+	}
+	for {
+		if err := s.catchUp(ctx); err != nil {
+			s.Logger.Warn("Failed to poll", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-done:
+			return
+		case <-time.After(s.PollInterval):
+		}
+	}
+}
+
+// catchUp delivers the head, then fetches and delivers the logs from s.next up to it, PageSize blocks at a time.
+func (s *Stream) catchUp(ctx context.Context) error {
+	head, err := s.HTTPClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if s.Heads != nil {
+		forkPoint, reorged, err := s.Heads.AddHead(ctx, head)
+		if err != nil {
+			return err
+		}
+		if reorged {
+			s.Rewind(forkPoint + 1)
+		}
+	}
+	s.deliverHead(ctx, head)
+	s.rewound = false
+	if s.next == Latest {
+		s.next = head.Number.Uint64() + 1
+		return nil
+	}
+	if s.OnLog == nil {
+		return nil
+	}
+	query := s.Query
+	for from := s.next; from <= head.Number.Uint64(); from += s.PageSize {
+		to := min(from+s.PageSize-1, head.Number.Uint64())
+		query.FromBlock = new(big.Int).SetUint64(from)
+		query.ToBlock = new(big.Int).SetUint64(to)
+		logs, err := s.HTTPClient.FilterLogs(ctx, query)
+		if err != nil {
+			return err
+		}
+		// nodes return logs in order, but cursors rely on it so make sure
+		sort.Slice(logs, func(i, j int) bool {
+			if logs[i].BlockNumber != logs[j].BlockNumber {
+				return logs[i].BlockNumber < logs[j].BlockNumber
+			}
+			return logs[i].Index < logs[j].Index
+		})
+		s.Metrics.LogsFetched(len(logs))
+		for _, vLog := range logs {
+			s.deliver(ctx, vLog)
+		}
+		s.next = to + 1
+	}
+	return nil
+}
+
+// deliver hands the log to OnLog, unless it was delivered already.
+func (s *Stream) deliver(ctx context.Context, vLog types.Log) {
+	key := logKey{blockHash: vLog.BlockHash, index: vLog.Index}
+	if vLog.Removed {
+		delete(s.delivered, key)
+		s.OnLog(ctx, vLog)
+		return
+	}
+	if _, ok := s.delivered[key]; ok {
+		return
+	}
+	s.delivered[key] = vLog.BlockNumber
+	if vLog.BlockNumber > s.lastBlock {
+		s.lastBlock = vLog.BlockNumber
+		for key, blockNumber := range s.delivered {
+			if blockNumber+dedupeWindow < s.lastBlock {
+				delete(s.delivered, key)
+			}
+		}
+	}
+	s.OnLog(ctx, vLog)
+}
+
+// deliverHead hands the head to OnHead, unless it is the last one it got.
+func (s *Stream) deliverHead(ctx context.Context, head *types.Header) {
+	if s.OnHead == nil {
+		return
+	}
+	hash := head.Hash()
+	if hash == s.lastHead {
+		return
+	}
+	s.lastHead = hash
+	s.OnHead(ctx, head)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) Transport(string)         {}
+func (noopMetrics) SubscriptionFailed()      {}
+func (noopMetrics) SubscriptionReconnected() {}
+func (noopMetrics) LogsFetched(int)          {}
+
+type noopLogger struct{}
+
+func (noopLogger) Info(string, ...any) {}
+func (noopLogger) Warn(string, ...any) {}
@@ -0,0 +1,27 @@
+package logstream
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// The task manager is a module of its own, which cannot import core/logstream of
+// the main module, so logstream.go is a copy of it. Changes go to core/logstream,
+// which has the tests, and are copied here.
+func TestSameAsCoreLogstream(t *testing.T) {
+	core, err := os.ReadFile("../../core/logstream/logstream.go")
+	if os.IsNotExist(err) {
+		t.Skip("core/logstream is not checked out")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	copied, err := os.ReadFile("logstream.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(core, copied) {
+		t.Error("logstream.go differs from core/logstream/logstream.go, copy it over again")
+	}
+}
@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+)
+
+const (
+	SinkWebhook = "webhook"
+	SinkSMTP    = "smtp"
+	SinkFile    = "file"
+)
+
+// Config is the notifications section of the task manager's config file.
+type Config struct {
+	Enabled     bool         `yaml:"enabled"`
+	Retry       Retry        `yaml:"retry"`
+	Sinks       []SinkConfig `yaml:"sinks"`
+	Subscribers []Subscriber `yaml:"subscribers"`
+}
+
+type SinkConfig struct {
+	Name string `yaml:"name"`
+	// Type is SinkWebhook, SinkSMTP or SinkFile.
+	Type string `yaml:"type"`
+
+	// webhook
+	URL string `yaml:"url"`
+	// SecretEnv is the environment variable holding the secret payloads are signed with.
+	SecretEnv string `yaml:"secret_env"`
+
+	// smtp
+	SMTPAddr    string   `yaml:"smtp_addr"`
+	Username    string   `yaml:"username"`
+	PasswordEnv string   `yaml:"password_env"`
+	From        string   `yaml:"from"`
+	To          []string `yaml:"to"`
+
+	// file, "-" for stdout
+	Path string `yaml:"path"`
+}
+
+// Validate returns every problem with the config, or nil.
+func (c Config) Validate() []error {
+	if !c.Enabled {
+		return nil
+	}
+	var errs []error
+	names := make(map[string]bool)
+	for i, sink := range c.Sinks {
+		prefix := fmt.Sprintf("notifications.sinks[%d]", i)
+		if sink.Name == "" {
+			errs = append(errs, fmt.Errorf("%s.name is required", prefix))
+		} else if names[sink.Name] {
+			errs = append(errs, fmt.Errorf("%s.name: %q is used by another sink", prefix, sink.Name))
+		}
+		names[sink.Name] = true
+		switch sink.Type {
+		case SinkWebhook:
+			if u, err := url.Parse(sink.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				errs = append(errs, fmt.Errorf("%s.url: %q is not an http url", prefix, sink.URL))
+			}
+			if sink.SecretEnv != "" && os.Getenv(sink.SecretEnv) == "" {
+				errs = append(errs, fmt.Errorf("%s.secret_env: %s is not set", prefix, sink.SecretEnv))
+			}
+		case SinkSMTP:
+			if _, _, err := net.SplitHostPort(sink.SMTPAddr); err != nil {
+				errs = append(errs, fmt.Errorf("%s.smtp_addr: %q is not host:port", prefix, sink.SMTPAddr))
+			}
+			if sink.From == "" || len(sink.To) == 0 {
+				errs = append(errs, fmt.Errorf("%s: from and to are required", prefix))
+			}
+		case SinkFile:
+			if sink.Path == "" {
+				errs = append(errs, fmt.Errorf("%s.path is required", prefix))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%s.type: %q is not %s, %s or %s", prefix, sink.Type, SinkWebhook, SinkSMTP, SinkFile))
+		}
+	}
+	for i, subscriber := range c.Subscribers {
+		prefix := fmt.Sprintf("notifications.subscribers[%d]", i)
+		if !names[subscriber.Sink] {
+			errs = append(errs, fmt.Errorf("%s.sink: there is no sink named %q", prefix, subscriber.Sink))
+		}
+		for _, event := range subscriber.Events {
+			if !ValidEventType(event) {
+				errs = append(errs, fmt.Errorf("%s.events: unknown event %q", prefix, event))
+			}
+		}
+	}
+	if c.Retry.MaxAttempts < 0 || c.Retry.InitialBackoff < 0 || c.Retry.MaxBackoff < 0 {
+		errs = append(errs, errors.New("notifications.retry: settings cannot be negative"))
+	}
+	return errs
+}
+
+// New builds the notifier of a valid config. It is a Nop when notifications are disabled.
+func New(c Config) (Notifier, error) {
+	if !c.Enabled {
+		return Nop{}, nil
+	}
+	sinks := make(map[string]Sink, len(c.Sinks))
+	for _, sink := range c.Sinks {
+		switch sink.Type {
+		case SinkWebhook:
+			sinks[sink.Name] = &WebhookSink{URL: sink.URL, Secret: os.Getenv(sink.SecretEnv)}
+		case SinkSMTP:
+			sinks[sink.Name] = &SMTPSink{
+				Addr:     sink.SMTPAddr,
+				Username: sink.Username,
+				Password: os.Getenv(sink.PasswordEnv),
+				From:     sink.From,
+				To:       sink.To,
+			}
+		case SinkFile:
+			fileSink, err := NewFileSink(sink.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open notification file of sink %s: %w", sink.Name, err)
+			}
+			sinks[sink.Name] = fileSink
+		}
+	}
+	return NewDispatcher(sinks, c.Subscribers, c.Retry)
+}
@@ -0,0 +1,195 @@
+// Package notify tells people about the lifecycle of Keeper jobs, through
+// webhooks, email or files. Which events of which jobs go to which sink is
+// configured per subscriber.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+)
+
+type EventType string
+
+const (
+	JobCreated         EventType = "job_created"
+	TaskAssigned       EventType = "task_assigned"
+	TaskMissedDeadline EventType = "task_missed_deadline"
+	TaskResponded      EventType = "task_responded"
+	// JobFailed is sent when a run of a job could not be completed, e.g. because no
+	// operator responded to its task, so that jobs do not stop running silently.
+	JobFailed EventType = "job_failed"
+)
+
+var eventTypes = []EventType{JobCreated, TaskAssigned, TaskMissedDeadline, TaskResponded, JobFailed}
+
+type Event struct {
+	Type   EventType `json:"type"`
+	JobID  uint32    `json:"jobId"`
+	TaskID uint32    `json:"taskId,omitempty"`
+	// Operator is the address of the operator the task was given to, if any.
+	Operator string    `json:"operator,omitempty"`
+	Message  string    `json:"message"`
+	Time     time.Time `json:"time"`
+}
+
+// Sink delivers events somewhere.
+type Sink interface {
+	Send(ctx context.Context, event Event) error
+}
+
+// Notifier sends events to the sinks of the subscribers that want them.
+type Notifier interface {
+	Notify(event Event)
+	// Run delivers the events until ctx is done.
+	Run(ctx context.Context)
+}
+
+// Nop is the Notifier used when notifications are disabled.
+type Nop struct{}
+
+func (Nop) Notify(Event) {}
+
+func (Nop) Run(context.Context) {}
+
+// Subscriber wants some events of some jobs sent to a sink.
+type Subscriber struct {
+	Sink string `yaml:"sink"`
+	// Jobs are the ids of the jobs whose events are sent. Empty means every job.
+	Jobs []uint32 `yaml:"jobs"`
+	// Events are the types of the events sent. Empty means every type.
+	Events []EventType `yaml:"events"`
+}
+
+func (s Subscriber) wants(event Event) bool {
+	return (len(s.Jobs) == 0 || slices.Contains(s.Jobs, event.JobID)) &&
+		(len(s.Events) == 0 || slices.Contains(s.Events, event.Type))
+}
+
+// Retry is how often and how fast a failed delivery is retried.
+type Retry struct {
+	MaxAttempts int `yaml:"max_attempts"`
+	// The wait between attempts starts at InitialBackoff and doubles up to MaxBackoff.
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+}
+
+const (
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+	queueSize             = 1000
+)
+
+// Dispatcher is a Notifier that queues the events and delivers them in the
+// background, so that a slow or unreachable sink does not hold up the task
+// manager. Every sink has its own queue and worker, so that it does not hold
+// up the other sinks either. Events that cannot be queued or delivered are
+// logged and dropped.
+type Dispatcher struct {
+	sinks       map[string]Sink
+	subscribers []Subscriber
+	retry       Retry
+	// queues holds the events waiting for delivery, by sink
+	queues map[string]chan Event
+}
+
+var _ Notifier = (*Dispatcher)(nil)
+
+func NewDispatcher(sinks map[string]Sink, subscribers []Subscriber, retry Retry) (*Dispatcher, error) {
+	for _, subscriber := range subscribers {
+		if _, ok := sinks[subscriber.Sink]; !ok {
+			return nil, fmt.Errorf("subscriber of unknown sink %q", subscriber.Sink)
+		}
+	}
+	if retry.MaxAttempts == 0 {
+		retry.MaxAttempts = defaultMaxAttempts
+	}
+	if retry.InitialBackoff == 0 {
+		retry.InitialBackoff = defaultInitialBackoff
+	}
+	if retry.MaxBackoff == 0 {
+		retry.MaxBackoff = defaultMaxBackoff
+	}
+	queues := make(map[string]chan Event, len(sinks))
+	for name := range sinks {
+		queues[name] = make(chan Event, queueSize)
+	}
+	return &Dispatcher{sinks: sinks, subscribers: subscribers, retry: retry, queues: queues}, nil
+}
+
+// Notify queues the event for every sink subscribed to it, once per sink.
+func (d *Dispatcher) Notify(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	queued := make(map[string]bool)
+	for _, subscriber := range d.subscribers {
+		if !subscriber.wants(event) || queued[subscriber.Sink] {
+			continue
+		}
+		queued[subscriber.Sink] = true
+		select {
+		case d.queues[subscriber.Sink] <- event:
+		default:
+			log.Printf("Notification queue is full, dropping %s notification of job %d for %s", event.Type, event.JobID, subscriber.Sink)
+		}
+	}
+}
+
+// Run delivers the queued events until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for name := range d.sinks {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			d.runSink(ctx, name)
+		}(name)
+	}
+	wg.Wait()
+}
+
+// runSink delivers the events queued for the sink, one at a time, until ctx is done.
+func (d *Dispatcher) runSink(ctx context.Context, name string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-d.queues[name]:
+			if err := d.deliver(ctx, d.sinks[name], event); err != nil {
+				log.Printf("Failed to deliver %s notification of job %d to %s: %v", event.Type, event.JobID, name, err)
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, sink Sink, event Event) error {
+	backoff := d.retry.InitialBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = sink.Send(ctx, event)
+		if err == nil || attempt >= d.retry.MaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, d.retry.MaxBackoff)
+	}
+	if err != nil {
+		return fmt.Errorf("after %d attempts: %w", d.retry.MaxAttempts, err)
+	}
+	return nil
+}
+
+// ValidEventType reports whether t is one of the event types.
+func ValidEventType(t EventType) bool {
+	return slices.Contains(eventTypes, t)
+}
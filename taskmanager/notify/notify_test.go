@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSink fails the first sends, then records the events.
+type fakeSink struct {
+	mu       sync.Mutex
+	failures int
+	events   []Event
+	sent     chan struct{}
+}
+
+func (s *fakeSink) Send(_ context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("unavailable")
+	}
+	s.events = append(s.events, event)
+	s.sent <- struct{}{}
+	return nil
+}
+
+func TestDispatcherRoutesAndRetries(t *testing.T) {
+	ops := &fakeSink{failures: 2, sent: make(chan struct{}, 10)}
+	owner := &fakeSink{sent: make(chan struct{}, 10)}
+	d, err := NewDispatcher(map[string]Sink{"ops": ops, "owner": owner}, []Subscriber{
+		{Sink: "ops", Events: []EventType{JobFailed}},
+		{Sink: "owner", Jobs: []uint32{7}},
+	}, Retry{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	d.Notify(Event{Type: TaskAssigned, JobID: 7})
+	d.Notify(Event{Type: JobFailed, JobID: 8})
+	d.Notify(Event{Type: JobFailed, JobID: 7})
+	for i := 0; i < 2; i++ {
+		<-ops.sent
+	}
+	for i := 0; i < 2; i++ {
+		<-owner.sent
+	}
+
+	if len(ops.events) != 2 || ops.events[0].JobID != 8 || ops.events[1].JobID != 7 {
+		t.Errorf("expected the failures of jobs 8 and 7 to reach ops after retries, got %+v", ops.events)
+	}
+	if len(owner.events) != 2 || owner.events[0].Type != TaskAssigned || owner.events[1].Type != JobFailed {
+		t.Errorf("expected every event of job 7 to reach its owner, got %+v", owner.events)
+	}
+}
+
+// blockedSink does not return from Send until ctx is done.
+type blockedSink struct{}
+
+func (blockedSink) Send(ctx context.Context, _ Event) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestSlowSinkDoesNotHoldUpTheOthers(t *testing.T) {
+	fast := &fakeSink{sent: make(chan struct{}, 10)}
+	d, err := NewDispatcher(map[string]Sink{"slow": blockedSink{}, "fast": fast}, []Subscriber{
+		{Sink: "slow"},
+		{Sink: "fast"},
+	}, Retry{MaxAttempts: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	for jobID := uint32(1); jobID <= 3; jobID++ {
+		d.Notify(Event{Type: JobCreated, JobID: jobID})
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case <-fast.sent:
+		case <-time.After(time.Second):
+			t.Fatalf("expected every event to reach the fast sink while the slow one blocks, got %d", i)
+		}
+	}
+}
+
+func TestWebhookPayloadsAreSigned(t *testing.T) {
+	var timestamp, signature string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp, signature = r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader)
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	sink := &WebhookSink{URL: server.URL, Secret: "s3cret"}
+	if err := sink.Send(context.Background(), Event{Type: JobCreated, JobID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if signature == "" || signature != Sign("s3cret", timestamp, body) {
+		t.Errorf("signature %q does not match the payload", signature)
+	}
+	if signature == Sign("other", timestamp, body) {
+		t.Error("expected the signature to depend on the secret")
+	}
+}
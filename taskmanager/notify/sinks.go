@@ -0,0 +1,138 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the
+	// timestamp header, a ".", and the body, keyed with the webhook's secret.
+	SignatureHeader = "X-Keeper-Signature"
+	TimestampHeader = "X-Keeper-Timestamp"
+)
+
+// WebhookSink posts the events as json to a url.
+type WebhookSink struct {
+	URL string
+	// Secret signs the payloads, see SignatureHeader. Empty means unsigned.
+	Secret string
+	Client *http.Client
+}
+
+func (s *WebhookSink) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(s.Secret, timestamp, body))
+	}
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value of a webhook payload, for receivers to check it with.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SMTPSink emails the events.
+type SMTPSink struct {
+	// Addr is the host:port of the mail server.
+	Addr     string
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+func (s *SMTPSink) Send(ctx context.Context, event Event) error {
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, err := net.SplitHostPort(s.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+	subject := fmt.Sprintf("[keeper] %s: job %d", event.Type, event.JobID)
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n", s.From, strings.Join(s.To, ", "), subject)
+	fmt.Fprintf(&msg, "%s\r\n\r\nJob: %d\r\n", event.Message, event.JobID)
+	if event.TaskID != 0 {
+		fmt.Fprintf(&msg, "Task: %d\r\n", event.TaskID)
+	}
+	if event.Operator != "" {
+		fmt.Fprintf(&msg, "Operator: %s\r\n", event.Operator)
+	}
+	fmt.Fprintf(&msg, "Time: %s\r\n", event.Time.Format(time.RFC3339))
+	// net/smtp takes no context, so the send is not cancelled with ctx
+	return smtp.SendMail(s.Addr, auth, s.From, s.To, []byte(msg.String()))
+}
+
+// FileSink appends the events as json lines to a file, or writes them to stdout.
+type FileSink struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewFileSink opens the file at path for appending. A path of "-" means stdout.
+func NewFileSink(path string) (*FileSink, error) {
+	if path == "-" {
+		return &FileSink{out: os.Stdout}, nil
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{out: file}, nil
+}
+
+func (s *FileSink) Send(_ context.Context, event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.out.Write(append(line, '\n'))
+	return err
+}
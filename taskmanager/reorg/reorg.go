@@ -0,0 +1,263 @@
+// Package reorg holds chain logs back until they are a number of blocks deep,
+// and reports the ones a chain reorganization removes after that.
+package reorg
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// blocks tracked beyond the confirmation depth, so reorgs deeper than it are still noticed
+const windowMargin = 64
+
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Detector tracks the hashes of the recent canonical blocks and tells from
+// every new head whether the chain was reorganized.
+type Detector struct {
+	headers HeaderReader
+	window  uint64
+	hashes  map[uint64]common.Hash
+	head    uint64
+	tail    uint64
+}
+
+func NewDetector(headers HeaderReader, window uint64) *Detector {
+	return &Detector{headers: headers, window: window, hashes: make(map[uint64]common.Hash)}
+}
+
+// Head returns the number of the latest head, and false if there is none yet.
+func (d *Detector) Head() (uint64, bool) {
+	return d.head, len(d.hashes) > 0
+}
+
+// CanonicalHash returns the hash of the canonical block at number, if it is tracked.
+func (d *Detector) CanonicalHash(number uint64) (common.Hash, bool) {
+	hash, ok := d.hashes[number]
+	return hash, ok
+}
+
+// AddHead records a new head. The blocks between it and the tracked chain are
+// read through the HeaderReader, so missed heads are filled in. If the new head
+// replaced tracked blocks, AddHead returns true and the number of the last
+// block the old and the new chain have in common.
+func (d *Detector) AddHead(ctx context.Context, head *types.Header) (forkPoint uint64, reorged bool, err error) {
+	number := head.Number.Uint64()
+	if len(d.hashes) == 0 || number < d.tail {
+		// the first head: the walk below reads the window of blocks before it
+		d.hashes = make(map[uint64]common.Hash)
+		d.head, d.tail = number, number-min(number, d.window)
+	}
+
+	lowestReplaced := uint64(math.MaxUint64)
+	// the new chain may be shorter than the old one
+	for n := number + 1; n <= d.head; n++ {
+		if _, ok := d.hashes[n]; ok {
+			delete(d.hashes, n)
+			lowestReplaced = min(lowestReplaced, n)
+		}
+	}
+	if hash, ok := d.hashes[number]; ok && hash != head.Hash() {
+		lowestReplaced = min(lowestReplaced, number)
+	}
+	d.hashes[number] = head.Hash()
+
+	// walk back from the new head until it joins the tracked chain
+	header := head
+	for header.Number.Uint64() > d.tail {
+		parent := header.Number.Uint64() - 1
+		hash, ok := d.hashes[parent]
+		if ok && hash == header.ParentHash {
+			break
+		}
+		if ok {
+			lowestReplaced = min(lowestReplaced, parent)
+		}
+		header, err = d.headers.HeaderByNumber(ctx, new(big.Int).SetUint64(parent))
+		if err != nil {
+			return 0, false, err
+		}
+		d.hashes[parent] = header.Hash()
+	}
+
+	d.head = number
+	for ; d.tail+d.window < d.head; d.tail++ {
+		delete(d.hashes, d.tail)
+	}
+	if lowestReplaced == math.MaxUint64 {
+		return 0, false, nil
+	}
+	return lowestReplaced - 1, true, nil
+}
+
+// Event is a log along with what it was decoded into.
+type Event[T any] struct {
+	Log   types.Log
+	Value T
+}
+
+// Update is what a new log or head changed.
+type Update[T any] struct {
+	// Confirmed are the events that just reached the confirmation depth, in chain order.
+	Confirmed []Event[T]
+	// Removed are confirmed events whose block is no longer canonical. If the
+	// new chain includes their logs again, they are confirmed again.
+	Removed []Event[T]
+	// Reorged is set when a head replaced blocks, and ForkPoint is then the
+	// number of the last block the old and the new chain have in common.
+	Reorged   bool
+	ForkPoint uint64
+}
+
+// Confirmer holds events back until their block is Depth blocks below the
+// head and still canonical. A zero depth confirms events as they arrive, but
+// still reports the confirmed events that later reorgs remove.
+//
+// Logs and heads come from separate subscriptions, so the log of a new chain
+// may arrive before the head that makes its block canonical. Events are
+// therefore matched to the canonical chain by their block hash, and the ones
+// of a block that is not canonical are held back until it becomes canonical
+// or falls out of the window reorgs are tracked in.
+type Confirmer[T any] struct {
+	depth    uint64
+	detector *Detector
+	pending  []pendingEvent[T]
+	// confirmed events recent enough to be removed by a reorg
+	confirmed []Event[T]
+}
+
+type pendingEvent[T any] struct {
+	Event[T]
+	// orphaned is set once the event's block was seen replaced
+	orphaned bool
+}
+
+func NewConfirmer[T any](depth uint64, headers HeaderReader) *Confirmer[T] {
+	return &Confirmer[T]{depth: depth, detector: NewDetector(headers, depth+windowMargin)}
+}
+
+// AddLog adds a log from a subscription or a log filter. A log the node marks
+// as removed drops its pending event, or removes its confirmed one. Logs that
+// were already added are ignored.
+func (c *Confirmer[T]) AddLog(log types.Log, value T) Update[T] {
+	if log.Removed {
+		c.pending = filter(c.pending, func(event pendingEvent[T]) bool { return !sameLog(event.Log, log) })
+		for _, event := range c.confirmed {
+			if sameLog(event.Log, log) {
+				c.confirmed = without(c.confirmed, log)
+				return Update[T]{Removed: []Event[T]{event}}
+			}
+		}
+		return Update[T]{}
+	}
+	for _, event := range c.pending {
+		if sameLog(event.Log, log) {
+			return Update[T]{}
+		}
+	}
+	if contains(c.confirmed, log) {
+		return Update[T]{}
+	}
+	c.pending = append(c.pending, pendingEvent[T]{Event: Event[T]{Log: log, Value: value}})
+	return Update[T]{Confirmed: c.confirm()}
+}
+
+// AddHead adds a new head of the chain.
+func (c *Confirmer[T]) AddHead(ctx context.Context, head *types.Header) (Update[T], error) {
+	forkPoint, reorged, err := c.detector.AddHead(ctx, head)
+	if err != nil {
+		return Update[T]{}, err
+	}
+	var update Update[T]
+	if reorged {
+		update.Reorged, update.ForkPoint = true, forkPoint
+		// pending events of the new chain may have arrived already, and are
+		// confirmed below once they are deep enough
+		var kept []Event[T]
+		for _, event := range c.confirmed {
+			if event.Log.BlockNumber <= forkPoint {
+				kept = append(kept, event)
+			} else {
+				update.Removed = append(update.Removed, event)
+				// confirmed again if a later reorg brings its block back
+				c.pending = append(c.pending, pendingEvent[T]{Event: event, orphaned: true})
+			}
+		}
+		c.confirmed = kept
+	}
+	update.Confirmed = c.confirm()
+	// a reorg cannot be detected below the detector's window
+	c.confirmed = filter(c.confirmed, func(event Event[T]) bool { return event.Log.BlockNumber >= c.detector.tail })
+	return update, nil
+}
+
+// confirm moves the pending events that are deep enough and canonical to
+// confirmed, and drops the ones whose block was replaced for good.
+func (c *Confirmer[T]) confirm() []Event[T] {
+	head, ok := c.detector.Head()
+	var confirmed []Event[T]
+	var pending []pendingEvent[T]
+	for _, event := range c.pending {
+		number := event.Log.BlockNumber
+		hash, tracked := c.detector.CanonicalHash(number)
+		switch {
+		case tracked && hash != event.Log.BlockHash:
+			// the block is not canonical, or the head of its chain has not arrived yet
+			event.orphaned = true
+		case event.orphaned && !tracked:
+			if ok && number < c.detector.tail {
+				// replaced for good
+				continue
+			}
+			// the new chain does not reach the block yet
+		case c.depth > 0 && (!ok || number+c.depth > head):
+		default:
+			confirmed = append(confirmed, event.Event)
+			continue
+		}
+		pending = append(pending, event)
+	}
+	c.pending = pending
+	sort.SliceStable(confirmed, func(i, j int) bool {
+		if confirmed[i].Log.BlockNumber != confirmed[j].Log.BlockNumber {
+			return confirmed[i].Log.BlockNumber < confirmed[j].Log.BlockNumber
+		}
+		return confirmed[i].Log.Index < confirmed[j].Log.Index
+	})
+	c.confirmed = append(c.confirmed, confirmed...)
+	return confirmed
+}
+
+func sameLog(a, b types.Log) bool {
+	return a.BlockHash == b.BlockHash && a.Index == b.Index
+}
+
+func contains[T any](events []Event[T], log types.Log) bool {
+	for _, event := range events {
+		if sameLog(event.Log, log) {
+			return true
+		}
+	}
+	return false
+}
+
+func without[T any](events []Event[T], log types.Log) []Event[T] {
+	return filter(events, func(event Event[T]) bool { return !sameLog(event.Log, log) })
+}
+
+func filter[E any](events []E, keep func(E) bool) []E {
+	var kept []E
+	for _, event := range events {
+		if keep(event) {
+			kept = append(kept, event)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,27 @@
+package reorg
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// The task manager is a module of its own, which cannot import core/reorg of
+// the main module, so reorg.go is a copy of it. Changes go to core/reorg,
+// which has the tests, and are copied here.
+func TestSameAsCoreReorg(t *testing.T) {
+	core, err := os.ReadFile("../../core/reorg/reorg.go")
+	if os.IsNotExist(err) {
+		t.Skip("core/reorg is not checked out")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	copied, err := os.ReadFile("reorg.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(core, copied) {
+		t.Error("reorg.go differs from core/reorg/reorg.go, copy it over again")
+	}
+}
@@ -0,0 +1,316 @@
+// Package scheduler runs jobs at the times their triggers give, within the
+// window of their timeframe. Schedules are kept on disk, so they survive
+// restarts.
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Schedule is when a job runs, along with what the run function needs to run it.
+type Schedule struct {
+	JobID   uint32  `json:"jobId"`
+	Trigger Trigger `json:"trigger"`
+	// Start is when the schedule starts, and End when it stops. A zero End means it does not stop.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end,omitempty"`
+	// Payload is handed to the run function as is.
+	Payload json.RawMessage `json:"payload,omitempty"`
+	// NextRun is when the job runs next.
+	NextRun time.Time `json:"nextRun"`
+	Runs    uint64    `json:"runs"`
+	// LastRun is when the job last ran.
+	LastRun time.Time `json:"lastRun,omitempty"`
+	// Ended marks the schedule of a job that no longer runs. It is kept so that
+	// scheduling the job again does not repeat the runs it already made.
+	Ended bool `json:"ended,omitempty"`
+}
+
+// advance moves NextRun to the first run after 'after', and reports whether there is one.
+func (s *Schedule) advance(after time.Time) bool {
+	if s.Trigger.Kind == TriggerEvent {
+		return s.End.IsZero() || after.Before(s.End)
+	}
+	next, ok := s.Trigger.next(s.Start, after)
+	if !ok || (!s.End.IsZero() && next.After(s.End)) {
+		return false
+	}
+	s.NextRun = next
+	return true
+}
+
+type Scheduler struct {
+	path string
+	run  func(Schedule)
+	now  func() time.Time
+
+	mu        sync.Mutex
+	schedules map[uint32]*Schedule
+	// ended holds the schedules that are over or cancelled
+	ended map[uint32]*Schedule
+	// wake tells Run that the earliest run may have changed
+	wake chan struct{}
+}
+
+// New loads the schedules kept at path. run is called in its own goroutine
+// for every run of a job.
+func New(path string, run func(Schedule)) (*Scheduler, error) {
+	s := &Scheduler{
+		path:      path,
+		run:       run,
+		now:       time.Now,
+		schedules: make(map[uint32]*Schedule),
+		ended:     make(map[uint32]*Schedule),
+		wake:      make(chan struct{}, 1),
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var schedules []*Schedule
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return nil, err
+	}
+	for _, schedule := range schedules {
+		if schedule.Ended {
+			s.ended[schedule.JobID] = schedule
+		} else {
+			s.schedules[schedule.JobID] = schedule
+		}
+	}
+	return s, nil
+}
+
+// Add schedules a job, replacing its previous schedule. The runs the job
+// already made are kept, so that a job scheduled again, e.g. when its events
+// are processed again after a restart, does not run before its last run
+// again. It returns false if the job would never run, e.g. because its
+// timeframe is over or it ran once already.
+func (s *Scheduler) Add(schedule Schedule) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule.Runs, schedule.LastRun, schedule.Ended = 0, time.Time{}, false
+	previous, ok := s.schedules[schedule.JobID]
+	if !ok {
+		previous, ok = s.ended[schedule.JobID]
+	}
+	if ok {
+		schedule.Runs, schedule.LastRun = previous.Runs, previous.LastRun
+	}
+	after := schedule.Start.Add(-time.Nanosecond)
+	if schedule.LastRun.After(after) {
+		after = schedule.LastRun
+	}
+	if !schedule.advance(after) || !schedule.catchUp(s.now()) {
+		s.end(&schedule)
+		s.notify()
+		return false, s.save()
+	}
+	delete(s.ended, schedule.JobID)
+	s.schedules[schedule.JobID] = &schedule
+	s.notify()
+	return true, s.save()
+}
+
+// end moves the schedule to the ended schedules. It must be called with mu held.
+func (s *Scheduler) end(schedule *Schedule) {
+	schedule.Ended = true
+	delete(s.schedules, schedule.JobID)
+	s.ended[schedule.JobID] = schedule
+}
+
+// catchUp moves NextRun of a schedule that should have run while the task
+// manager was down to now, so that it runs once rather than once per missed run.
+// It returns false if the schedule is over.
+func (s *Schedule) catchUp(now time.Time) bool {
+	if s.Trigger.Kind == TriggerEvent {
+		return s.End.IsZero() || !now.After(s.End)
+	}
+	if !s.NextRun.Before(now) {
+		return true
+	}
+	if !s.End.IsZero() && now.After(s.End) {
+		return false
+	}
+	s.NextRun = now
+	return true
+}
+
+// Cancel ends the job's schedule, and reports whether it had one. The runs
+// the job made are kept, as they are for a schedule that is over.
+func (s *Scheduler) Cancel(jobID uint32) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule, ok := s.schedules[jobID]
+	if !ok {
+		return false, nil
+	}
+	s.end(schedule)
+	s.notify()
+	return true, s.save()
+}
+
+// Fire counts a run of an event-triggered job, and returns its schedule for
+// the caller to run it with. It returns false if the job has no event trigger
+// or its schedule is over, in which case the schedule is removed.
+func (s *Scheduler) Fire(jobID uint32) (Schedule, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule, ok := s.schedules[jobID]
+	if !ok || schedule.Trigger.Kind != TriggerEvent {
+		return Schedule{}, false, nil
+	}
+	now := s.now()
+	if !schedule.catchUp(now) {
+		s.end(schedule)
+		return Schedule{}, false, s.save()
+	}
+	schedule.Runs++
+	schedule.LastRun = now
+	return *schedule, true, s.save()
+}
+
+// List returns the schedules of all jobs.
+func (s *Scheduler) List() []Schedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedules := make([]Schedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		schedules = append(schedules, *schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].JobID < schedules[j].JobID })
+	return schedules
+}
+
+// Get returns the job's schedule.
+func (s *Scheduler) Get(jobID uint32) (Schedule, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule, ok := s.schedules[jobID]
+	if !ok {
+		return Schedule{}, false
+	}
+	return *schedule, true
+}
+
+// Run runs the jobs as their schedules come due, until ctx is done. Jobs
+// that were due while the task manager was down run once right away.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	now := s.now()
+	for jobID, schedule := range s.schedules {
+		if !schedule.catchUp(now) {
+			log.Printf("Schedule of job %d ended while the task manager was down", jobID)
+			s.end(schedule)
+		}
+	}
+	s.mu.Unlock()
+
+	for {
+		wait := s.runDue()
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// the longest Run sleeps without looking at the schedules again
+const maxWait = time.Minute
+
+// runDue runs the due jobs, and returns how long to wait for the next one.
+func (s *Scheduler) runDue() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	var due []*Schedule
+	ended := false
+	for jobID, schedule := range s.schedules {
+		switch {
+		case schedule.Trigger.Kind == TriggerEvent:
+			if !schedule.catchUp(now) {
+				log.Printf("Schedule of job %d ended after %d runs", jobID, schedule.Runs)
+				s.end(schedule)
+				ended = true
+			}
+		case !schedule.NextRun.After(now):
+			due = append(due, schedule)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextRun.Before(due[j].NextRun) })
+	for _, schedule := range due {
+		schedule.Runs++
+		schedule.LastRun = now
+		go s.run(*schedule)
+		if !schedule.advance(now) {
+			log.Printf("Schedule of job %d ended after %d runs", schedule.JobID, schedule.Runs)
+			s.end(schedule)
+		}
+	}
+	if len(due) > 0 || ended {
+		if err := s.save(); err != nil {
+			log.Printf("Failed to save schedules: %v", err)
+		}
+	}
+
+	wait := maxWait
+	for _, schedule := range s.schedules {
+		if schedule.Trigger.Kind != TriggerEvent {
+			wait = min(wait, schedule.NextRun.Sub(now))
+		}
+	}
+	return max(wait, 0)
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// save writes the schedules to disk. It must be called with mu held.
+func (s *Scheduler) save() error {
+	schedules := make([]*Schedule, 0, len(s.schedules)+len(s.ended))
+	for _, schedule := range s.schedules {
+		schedules = append(schedules, schedule)
+	}
+	for _, schedule := range s.ended {
+		schedules = append(schedules, schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].JobID < schedules[j].JobID })
+	data, err := json.MarshalIndent(schedules, "", "  ")
+	if err != nil {
+		return err
+	}
+	// written to a temporary file first, so a crash never leaves a partial file behind
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".schedules-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
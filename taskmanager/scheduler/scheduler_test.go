@@ -0,0 +1,170 @@
+package scheduler
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseTrigger(t *testing.T) {
+	for spec, want := range map[string]Trigger{
+		"":                 {Kind: TriggerOnce},
+		"once":             {Kind: TriggerOnce},
+		"every 90s":        {Kind: TriggerInterval, Interval: 90 * time.Second},
+		"cron */5 * * * *": {Kind: TriggerCron, Cron: "*/5 * * * *"},
+	} {
+		got, err := ParseTrigger(spec)
+		if err != nil {
+			t.Errorf("%q: %v", spec, err)
+		} else if got != want {
+			t.Errorf("%q: expected %+v, got %+v", spec, want, got)
+		}
+	}
+	for _, spec := range []string{"every 0s", "every 1ms", "every soon", "cron * *", "daily"} {
+		if _, err := ParseTrigger(spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestIntervalScheduleStopsAtEnd(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := make(chan Schedule, 10)
+	s, err := New(filepath.Join(t.TempDir(), "schedules.json"), func(schedule Schedule) { runs <- schedule })
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := start
+	s.now = func() time.Time { return now }
+	trigger, _ := ParseTrigger("every 10s")
+	if ok, err := s.Add(Schedule{JobID: 1, Trigger: trigger, Start: start, End: start.Add(25 * time.Second)}); !ok || err != nil {
+		t.Fatalf("expected the job to be scheduled, got %v, %v", ok, err)
+	}
+
+	for i := 0; i < 5; i++ {
+		s.runDue()
+		now = now.Add(10 * time.Second)
+	}
+	// the runs are in their own goroutines, so they may arrive in any order
+	seen := make(map[uint64]bool)
+	for i := 0; i < 3; i++ {
+		seen[(<-runs).Runs] = true
+	}
+	if !seen[1] || !seen[2] || !seen[3] {
+		t.Errorf("expected runs 1 to 3, got %v", seen)
+	}
+	if len(runs) != 0 {
+		t.Errorf("expected 3 runs, got %d more", len(runs))
+	}
+	if _, ok := s.Get(1); ok {
+		t.Error("expected the schedule to be removed once over")
+	}
+}
+
+func TestSchedulesSurviveRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.json")
+	s, err := New(path, func(Schedule) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	trigger, _ := ParseTrigger("cron 0 * * * *")
+	for jobID := uint32(1); jobID <= 2; jobID++ {
+		if _, err := s.Add(Schedule{JobID: jobID, Trigger: trigger, Start: start}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Cancel(2); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = New(path, func(Schedule) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule, ok := s.Get(1)
+	if !ok {
+		t.Fatal("expected job 1 to be scheduled after a restart")
+	}
+	if schedule.Trigger != trigger || schedule.NextRun.Before(start) {
+		t.Errorf("unexpected schedule %+v", schedule)
+	}
+	if _, ok := s.Get(2); ok {
+		t.Error("expected cancelled job 2 to stay cancelled after a restart")
+	}
+}
+
+func TestEventScheduleRunsWhenFired(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := make(chan Schedule, 10)
+	s, err := New(filepath.Join(t.TempDir(), "schedules.json"), func(schedule Schedule) { runs <- schedule })
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := start
+	s.now = func() time.Time { return now }
+	if ok, err := s.Add(Schedule{JobID: 1, Trigger: Trigger{Kind: TriggerEvent}, Start: start, End: start.Add(time.Minute)}); !ok || err != nil {
+		t.Fatalf("expected the job to be scheduled, got %v, %v", ok, err)
+	}
+
+	s.runDue()
+	if len(runs) != 0 {
+		t.Errorf("expected no timed runs of an event schedule, got %d", len(runs))
+	}
+	if schedule, ok, err := s.Fire(1); !ok || err != nil || schedule.Runs != 1 {
+		t.Errorf("expected the first run, got %+v, %v, %v", schedule, ok, err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok, _ := s.Fire(1); ok {
+		t.Error("expected no run after the end of the schedule")
+	}
+	if _, ok := s.Get(1); ok {
+		t.Error("expected the schedule to be removed once over")
+	}
+}
+
+func TestAddKeepsTheRunsOfTheJob(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	runs := make(chan Schedule, 10)
+	path := filepath.Join(t.TempDir(), "schedules.json")
+	s, err := New(path, func(schedule Schedule) { runs <- schedule })
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := start
+	s.now = func() time.Time { return now }
+	once := Schedule{JobID: 1, Trigger: Trigger{Kind: TriggerOnce}, Start: start}
+	every, _ := ParseTrigger("every 10s")
+	interval := Schedule{JobID: 2, Trigger: every, Start: start}
+	for _, schedule := range []Schedule{once, interval} {
+		if ok, err := s.Add(schedule); !ok || err != nil {
+			t.Fatalf("expected job %d to be scheduled, got %v, %v", schedule.JobID, ok, err)
+		}
+	}
+	s.runDue()
+	<-runs
+	<-runs
+
+	// the jobs are scheduled again after a restart, e.g. when their events are processed again
+	now = now.Add(5 * time.Second)
+	s, err = New(path, func(schedule Schedule) { runs <- schedule })
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return now }
+	if ok, err := s.Add(once); ok || err != nil {
+		t.Errorf("expected the job that ran once not to be scheduled again, got %v, %v", ok, err)
+	}
+	if ok, err := s.Add(interval); !ok || err != nil {
+		t.Fatalf("expected the interval job to be scheduled again, got %v, %v", ok, err)
+	}
+	schedule, _ := s.Get(2)
+	if schedule.Runs != 1 || !schedule.NextRun.Equal(start.Add(10*time.Second)) {
+		t.Errorf("expected 1 run and the next at %s, got %d and %s", start.Add(10*time.Second), schedule.Runs, schedule.NextRun)
+	}
+	s.runDue()
+	if len(runs) != 0 {
+		t.Errorf("expected no run before the next one is due, got %d", len(runs))
+	}
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+type TriggerKind string
+
+const (
+	// TriggerOnce runs the job once, when its schedule starts.
+	TriggerOnce TriggerKind = "once"
+	// TriggerInterval runs the job when its schedule starts, then every Interval.
+	TriggerInterval TriggerKind = "interval"
+	// TriggerCron runs the job at the times matching a standard 5-field cron expression.
+	TriggerCron TriggerKind = "cron"
+	// TriggerEvent runs the job whenever its contract event is emitted while the
+	// schedule lasts. The runs are not timed, but fired with Scheduler.Fire.
+	TriggerEvent TriggerKind = "event"
+)
+
+// MinInterval is the shortest interval a job runs at. Every run is a task on
+// chain that every operator executes, so shorter intervals flood them.
+const MinInterval = 10 * time.Second
+
+// Trigger says when a scheduled job runs.
+type Trigger struct {
+	Kind     TriggerKind   `json:"kind"`
+	Interval time.Duration `json:"interval,omitempty"`
+	Cron     string        `json:"cron,omitempty"`
+}
+
+// ParseTrigger parses "once", "every <duration>" (e.g. "every 30s") or "cron <expression>"
+// (e.g. "cron */5 * * * *"). An empty spec is "once".
+func ParseTrigger(spec string) (Trigger, error) {
+	spec = strings.TrimSpace(spec)
+	kind, arg, _ := strings.Cut(spec, " ")
+	arg = strings.TrimSpace(arg)
+	switch {
+	case spec == "" || spec == "once":
+		return Trigger{Kind: TriggerOnce}, nil
+	case kind == "every":
+		interval, err := time.ParseDuration(arg)
+		if err != nil {
+			return Trigger{}, fmt.Errorf("invalid interval %q: %w", arg, err)
+		}
+		if interval < MinInterval {
+			return Trigger{}, fmt.Errorf("interval must be at least %s, got %s", MinInterval, interval)
+		}
+		return Trigger{Kind: TriggerInterval, Interval: interval}, nil
+	case kind == "cron":
+		if _, err := cron.ParseStandard(arg); err != nil {
+			return Trigger{}, fmt.Errorf("invalid cron expression %q: %w", arg, err)
+		}
+		return Trigger{Kind: TriggerCron, Cron: arg}, nil
+	default:
+		return Trigger{}, fmt.Errorf("unknown trigger %q, expected once, every <duration> or cron <expression>", spec)
+	}
+}
+
+func (t Trigger) String() string {
+	switch t.Kind {
+	case TriggerInterval:
+		return "every " + t.Interval.String()
+	case TriggerCron:
+		return "cron " + t.Cron
+	default:
+		return string(t.Kind)
+	}
+}
+
+// next returns the first time after 'after' that a schedule starting at start runs, and
+// false if it never runs after it.
+func (t Trigger) next(start, after time.Time) (time.Time, bool) {
+	switch t.Kind {
+	case TriggerOnce:
+		if after.Before(start) {
+			return start, true
+		}
+		return time.Time{}, false
+	case TriggerInterval:
+		if after.Before(start) {
+			return start, true
+		}
+		runs := after.Sub(start)/t.Interval + 1
+		return start.Add(runs * t.Interval), true
+	case TriggerCron:
+		schedule, err := cron.ParseStandard(t.Cron)
+		if err != nil {
+			return time.Time{}, false
+		}
+		if after.Before(start) {
+			// the times are matched to the second, so a run at start itself counts
+			after = start.Add(-time.Second)
+		}
+		next := schedule.Next(after)
+		return next, !next.IsZero()
+	default:
+		return time.Time{}, false
+	}
+}
@@ -0,0 +1,106 @@
+package taskmanager
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	AssignmentRoundRobin    = "round-robin"
+	AssignmentStakeWeighted = "stake-weighted"
+	AssignmentLeastLoaded   = "least-loaded"
+)
+
+// AssignmentRequest is what an AssignmentPolicy picks an operator for.
+type AssignmentRequest struct {
+	JobID  uint32
+	TaskID uint32
+	// BlockHash is the hash of the block the candidates were read at. It
+	// seeds the random policies, so that anyone can check their picks.
+	BlockHash common.Hash
+	// Load returns the number of tasks the operator is running.
+	Load func(operator common.Address) int
+}
+
+// AssignmentPolicy picks the operator that runs a task.
+type AssignmentPolicy interface {
+	// Pick returns one of the candidates, which are sorted by address and never empty.
+	Pick(request AssignmentRequest, candidates []Operator) Operator
+}
+
+// NewAssignmentPolicy returns the policy with the name, one of AssignmentRoundRobin,
+// AssignmentStakeWeighted or AssignmentLeastLoaded.
+func NewAssignmentPolicy(name string) (AssignmentPolicy, error) {
+	switch name {
+	case AssignmentRoundRobin:
+		return &roundRobinPolicy{}, nil
+	case AssignmentStakeWeighted:
+		return stakeWeightedPolicy{}, nil
+	case AssignmentLeastLoaded:
+		return leastLoadedPolicy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown assignment policy %q, expected %s, %s or %s",
+			name, AssignmentRoundRobin, AssignmentStakeWeighted, AssignmentLeastLoaded)
+	}
+}
+
+// roundRobinPolicy gives the tasks to the candidates in turn.
+type roundRobinPolicy struct {
+	mu   sync.Mutex
+	next uint64
+}
+
+func (p *roundRobinPolicy) Pick(_ AssignmentRequest, candidates []Operator) Operator {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	operator := candidates[p.next%uint64(len(candidates))]
+	p.next++
+	return operator
+}
+
+// stakeWeightedPolicy picks a candidate at random, with a chance proportional
+// to its stake. The randomness comes from the block hash and the task id.
+type stakeWeightedPolicy struct{}
+
+func (stakeWeightedPolicy) Pick(request AssignmentRequest, candidates []Operator) Operator {
+	total := new(big.Int)
+	for _, operator := range candidates {
+		total.Add(total, operator.Stake)
+	}
+	var taskID [4]byte
+	binary.BigEndian.PutUint32(taskID[:], request.TaskID)
+	seed := new(big.Int).SetBytes(crypto.Keccak256(request.BlockHash.Bytes(), taskID[:]))
+	if total.Sign() == 0 {
+		// no stake to weigh by, every candidate has the same chance
+		return candidates[seed.Mod(seed, big.NewInt(int64(len(candidates)))).Int64()]
+	}
+	point := seed.Mod(seed, total)
+	for _, operator := range candidates {
+		if point.Cmp(operator.Stake) < 0 {
+			return operator
+		}
+		point.Sub(point, operator.Stake)
+	}
+	return candidates[len(candidates)-1]
+}
+
+// leastLoadedPolicy picks the candidate running the fewest tasks, and the
+// one with the most stake among those.
+type leastLoadedPolicy struct{}
+
+func (leastLoadedPolicy) Pick(request AssignmentRequest, candidates []Operator) Operator {
+	best := candidates[0]
+	bestLoad := request.Load(best.Address)
+	for _, operator := range candidates[1:] {
+		load := request.Load(operator.Address)
+		if load < bestLoad || (load == bestLoad && operator.Stake.Cmp(best.Stake) > 0) {
+			best, bestLoad = operator, load
+		}
+	}
+	return best
+}
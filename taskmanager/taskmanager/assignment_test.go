@@ -0,0 +1,54 @@
+package taskmanager
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func testOperators(stakes ...int64) []Operator {
+	operators := make([]Operator, len(stakes))
+	for i, stake := range stakes {
+		operators[i] = Operator{Address: common.BigToAddress(big.NewInt(int64(i + 1))), Stake: big.NewInt(stake)}
+	}
+	return operators
+}
+
+func TestStakeWeightedPolicyFollowsStake(t *testing.T) {
+	candidates := testOperators(1, 0, 3)
+	picks := make(map[common.Address]int)
+	for taskID := uint32(1); taskID <= 4000; taskID++ {
+		request := AssignmentRequest{TaskID: taskID, BlockHash: common.HexToHash("0xabc")}
+		operator := stakeWeightedPolicy{}.Pick(request, candidates)
+		if again := (stakeWeightedPolicy{}).Pick(request, candidates); again.Address != operator.Address {
+			t.Fatalf("task %d: expected the same pick for the same seed", taskID)
+		}
+		picks[operator.Address]++
+	}
+	if picks[candidates[1].Address] != 0 {
+		t.Errorf("expected the operator without stake to never be picked, got %d picks", picks[candidates[1].Address])
+	}
+	if ratio := float64(picks[candidates[2].Address]) / float64(picks[candidates[0].Address]); ratio < 2.5 || ratio > 3.5 {
+		t.Errorf("expected about 3 times as many picks for 3 times the stake, got %v", picks)
+	}
+}
+
+func TestLeastLoadedPolicy(t *testing.T) {
+	candidates := testOperators(1, 5, 2)
+	loads := map[common.Address]int{candidates[0].Address: 0, candidates[1].Address: 1, candidates[2].Address: 0}
+	request := AssignmentRequest{Load: func(operator common.Address) int { return loads[operator] }}
+	if got := (leastLoadedPolicy{}).Pick(request, candidates); got.Address != candidates[2].Address {
+		t.Errorf("expected the idle operator with the most stake, got %s", got.Address.Hex())
+	}
+}
+
+func TestRoundRobinPolicy(t *testing.T) {
+	candidates := testOperators(1, 1, 1)
+	policy := &roundRobinPolicy{}
+	for i := 0; i < 6; i++ {
+		if got := policy.Pick(AssignmentRequest{}, candidates); got.Address != candidates[i%3].Address {
+			t.Errorf("pick %d: expected %s, got %s", i, candidates[i%3].Address.Hex(), got.Address.Hex())
+		}
+	}
+}
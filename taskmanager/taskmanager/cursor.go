@@ -0,0 +1,106 @@
+package taskmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// logPosition orders logs by block number, then by index within the block.
+type logPosition struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	LogIndex    uint   `json:"logIndex"`
+}
+
+func positionOf(vLog types.Log) logPosition {
+	return logPosition{BlockNumber: vLog.BlockNumber, LogIndex: vLog.Index}
+}
+
+func (p logPosition) after(other logPosition) bool {
+	if p.BlockNumber != other.BlockNumber {
+		return p.BlockNumber > other.BlockNumber
+	}
+	return p.LogIndex > other.LogIndex
+}
+
+// cursor is the position of the last log the task manager processed. It is
+// written to disk after every log, so a restarted task manager resumes right
+// after it without skipping or repeating logs.
+type cursor struct {
+	path     string
+	position *logPosition
+}
+
+// loadCursor reads the cursor at path. A missing file means no log has been processed yet.
+func loadCursor(path string) (*cursor, error) {
+	c := &cursor{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var position logPosition
+	if err := json.Unmarshal(data, &position); err != nil {
+		return nil, err
+	}
+	c.position = &position
+	return c, nil
+}
+
+// processed reports whether the log is at or before the cursor.
+func (c *cursor) processed(vLog types.Log) bool {
+	return c.position != nil && !positionOf(vLog).after(*c.position)
+}
+
+// resumeBlock returns the first block to fetch logs from: the cursor's block,
+// since it may have logs after the cursor, or startBlock if there is no cursor.
+func (c *cursor) resumeBlock(startBlock uint64) uint64 {
+	if c.position == nil {
+		return startBlock
+	}
+	return c.position.BlockNumber
+}
+
+func (c *cursor) advance(vLog types.Log) error {
+	return c.save(positionOf(vLog))
+}
+
+// rewind moves the cursor back to the end of block blockNumber, if it is past
+// it, so that the logs of the blocks after it are processed again.
+func (c *cursor) rewind(blockNumber uint64) error {
+	if c.position == nil || c.position.BlockNumber <= blockNumber {
+		return nil
+	}
+	return c.save(logPosition{BlockNumber: blockNumber, LogIndex: math.MaxUint})
+}
+
+func (c *cursor) save(position logPosition) error {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return err
+	}
+	// written to a temporary file first, so a crash never leaves a partial cursor behind
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".cursor-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return err
+	}
+	c.position = &position
+	return nil
+}
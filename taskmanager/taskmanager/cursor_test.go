@@ -0,0 +1,57 @@
+package taskmanager
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func testLog(blockNumber uint64, index uint) types.Log {
+	// an unknown event, so handling it only logs
+	return types.Log{
+		Address:     testJobManagerAddr,
+		Topics:      []common.Hash{{1}},
+		BlockNumber: blockNumber,
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(blockNumber + 1)),
+		Index:       index,
+	}
+}
+
+func TestCursorResumesAfterLastProcessedLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursor.json")
+	c, err := loadCursor(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from := c.resumeBlock(7); from != 7 {
+		t.Fatalf("expected a new cursor to start from the start block, got %d", from)
+	}
+	for _, vLog := range []types.Log{testLog(3, 0), testLog(12, 1), testLog(12, 4)} {
+		if err := c.advance(vLog); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a restarted task manager resumes from the cursor's block and skips what it already processed
+	c, err = loadCursor(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from := c.resumeBlock(7); from != 12 {
+		t.Fatalf("expected to resume from block 12, got %d", from)
+	}
+	if !c.processed(testLog(12, 1)) || c.processed(testLog(12, 5)) {
+		t.Error("expected only logs up to block 12 log 4 to be processed")
+	}
+
+	// a reorg from block 10 replaced block 12
+	if err := c.rewind(10); err != nil {
+		t.Fatal(err)
+	}
+	if c.processed(testLog(11, 0)) || !c.processed(testLog(10, 3)) {
+		t.Error("expected the logs after block 10 to be processed again")
+	}
+}
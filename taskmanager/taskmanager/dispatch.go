@@ -0,0 +1,339 @@
+package taskmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"taskmanager/notify"
+	"taskmanager/scheduler"
+)
+
+const (
+	// operatorRequestTimeout bounds the request that sends a task to an operator.
+	operatorRequestTimeout = 10 * time.Second
+	// defaultOperatorRetryAfter is how long a busy operator that does not say
+	// when to come back is left alone.
+	defaultOperatorRetryAfter = 5 * time.Second
+)
+
+var operatorClient = &http.Client{Timeout: operatorRequestTimeout}
+
+// operatorBusyError is returned when all the workers of an operator are busy.
+// The task should be sent to it again after retryAfter.
+type operatorBusyError struct {
+	retryAfter time.Duration
+}
+
+func (e *operatorBusyError) Error() string {
+	return fmt.Sprintf("operator is busy, retry after %s", e.retryAfter)
+}
+
+// scheduledTask is the payload of a job's schedule: the task sent on every
+// run, and the quorums whose operators run it.
+type scheduledTask struct {
+	OperatorTask
+	QuorumNumbers []byte `json:"quorumNumbers,omitempty"`
+	// QuorumThresholdPercentage is the share of the quorums' stake that has
+	// to sign the task's response.
+	QuorumThresholdPercentage uint32 `json:"quorumThresholdPercentage,omitempty"`
+	// FromBlock is the block the events of an event-triggered job are
+	// followed from.
+	FromBlock uint64 `json:"fromBlock,omitempty"`
+}
+
+// runSchedule creates a task for a run of a scheduled job and sends it to an operator.
+func (tm *TaskManager) runSchedule(schedule scheduler.Schedule) {
+	task, err := decodeScheduledTask(schedule)
+	if err != nil {
+		log.Printf("Failed to decode task of job %d: %v", schedule.JobID, err)
+		return
+	}
+	tm.runTask(context.Background(), schedule, task)
+}
+
+func decodeScheduledTask(schedule scheduler.Schedule) (scheduledTask, error) {
+	var task scheduledTask
+	err := json.Unmarshal(schedule.Payload, &task)
+	return task, err
+}
+
+// runTask creates the task of a run of a job and sends it to an operator.
+func (tm *TaskManager) runTask(ctx context.Context, schedule scheduler.Schedule, task scheduledTask) {
+	if len(task.QuorumNumbers) == 0 || task.QuorumThresholdPercentage == 0 {
+		// scheduled before the quorums were kept in the schedule
+		job, err := tm.loadJob(ctx, schedule.JobID)
+		if err != nil {
+			log.Printf("Failed to load job %d: %v", schedule.JobID, err)
+			return
+		}
+		task.QuorumNumbers = job.QuorumNumbers
+		task.QuorumThresholdPercentage = job.QuorumThresholdPercentage
+	}
+	if err := tm.dispatch(ctx, task, schedule.End); err != nil {
+		log.Printf("Failed to dispatch task of job %d: %v", schedule.JobID, err)
+		tm.config.Notifier.Notify(notify.Event{
+			Type:    notify.JobFailed,
+			JobID:   schedule.JobID,
+			TaskID:  task.TaskID,
+			Message: fmt.Sprintf("Run %d of job %d could not be given to an operator: %v", schedule.Runs, schedule.JobID, err),
+		})
+	}
+}
+
+// dispatch creates the task on chain and gives it to an operator. end is the
+// end of the job's timeframe, after which the task is not reassigned.
+func (tm *TaskManager) dispatch(ctx context.Context, task scheduledTask, end time.Time) error {
+	head, err := tm.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get head: %w", err)
+	}
+	task.BlockNumber = head.Number.Uint64()
+	if tm.writer != nil {
+		task.TaskID, err = tm.writer.CreateTask(ctx, task.JobID, task.JobType)
+		if err != nil {
+			return fmt.Errorf("failed to create task: %w", err)
+		}
+		log.Printf("Created task %d of job %d", task.TaskID, task.JobID)
+	}
+	if err := tm.assign(ctx, &pendingTask{task: task, tried: make(map[common.Address]bool), end: end}); err != nil {
+		tm.updateTaskStatus(ctx, task.TaskID, TaskStatusFailed)
+		return err
+	}
+	return nil
+}
+
+// assign picks an operator of the job's quorums that the task was not given
+// to yet with the assignment policy, records the assignment on chain and
+// sends the task to the operator. If the operator cannot be reached, the task
+// is failed right away (see failTask). The task is also sent to as many other
+// operators as its response needs the signatures of to meet the job's quorum
+// threshold. They only attest to the response, and do not submit upkeeps,
+// but when the task is reassigned, it goes to one of them if it can, since
+// they run it already.
+func (tm *TaskManager) assign(ctx context.Context, pending *pendingTask) error {
+	task := pending.task
+	head, err := tm.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get head: %w", err)
+	}
+	operators, err := tm.operators.operators(ctx, task.QuorumNumbers, head.Number.Uint64())
+	if err != nil {
+		return err
+	}
+	tm.pendingMu.Lock()
+	attesting := operatorsByAddress(operators, pending.attesters)
+	tm.pendingMu.Unlock()
+	candidates := tm.eligible(operators, pending.tried)
+	if len(candidates) == 0 {
+		return fmt.Errorf("no operator of quorums %v is left to run it", task.QuorumNumbers)
+	}
+
+	operator := tm.policy.Pick(AssignmentRequest{
+		JobID:     task.JobID,
+		TaskID:    task.TaskID,
+		BlockHash: head.Hash(),
+		Load:      tm.load,
+	}, promotable(candidates, attesting))
+	if tm.writer != nil {
+		if err := tm.writer.AssignTask(ctx, task.TaskID, operator.Address); err != nil {
+			return fmt.Errorf("failed to assign task: %w", err)
+		}
+		tm.updateTaskStatus(ctx, task.TaskID, TaskStatusAssigned)
+	}
+	pending.operator = operator.Address
+	pending.attempts++
+	pending.deadline = tm.deadline(time.Now(), pending.end)
+	log.Printf("Assigned task %d of job %d to operator %s, due by %s (attempt %d/%d)", task.TaskID, task.JobID,
+		operator.Address.Hex(), pending.deadline.Format(time.RFC3339), pending.attempts, tm.config.MaxTaskAttempts)
+
+	tm.config.Notifier.Notify(notify.Event{
+		Type:     notify.TaskAssigned,
+		JobID:    task.JobID,
+		TaskID:   task.TaskID,
+		Operator: operator.Address.Hex(),
+		Message: fmt.Sprintf("Task %d of job %d was given to operator %s, due by %s",
+			task.TaskID, task.JobID, operator.Address.Hex(), pending.deadline.Format(time.RFC3339)),
+	})
+
+	tm.pendingMu.Lock()
+	pending.tried[operator.Address] = true
+	if pending.attesters == nil {
+		pending.attesters = make(map[common.Address]bool)
+	}
+	delete(pending.attesters, operator.Address)
+	var others []Operator
+	for _, candidate := range candidates {
+		if candidate.Address != operator.Address && !pending.attesters[candidate.Address] {
+			others = append(others, candidate)
+		}
+	}
+	attested := operatorsByAddress(operators, pending.attesters)
+	attesters := pickAttesters(attested, others, operator, task.QuorumThresholdPercentage, totalStake(operators))
+	for _, attester := range attesters {
+		pending.attesters[attester.Address] = true
+	}
+	tm.pendingMu.Unlock()
+	deadline := pending.deadline
+	tm.track(pending)
+
+	for _, attester := range attesters {
+		go func(attester Operator) {
+			if err := sendTask(ctx, attester, task.OperatorTask, deadline); err != nil {
+				log.Printf("Failed to send task %d of job %d to attesting operator %s: %v", task.TaskID, task.JobID, attester.Address.Hex(), err)
+				// it does not run the task, so it cannot take it over
+				tm.pendingMu.Lock()
+				delete(pending.attesters, attester.Address)
+				pending.tried[attester.Address] = true
+				tm.pendingMu.Unlock()
+			}
+		}(attester)
+	}
+	assigned := task.OperatorTask
+	assigned.SubmitUpkeep = true
+	if err := sendTask(ctx, operator, assigned, deadline); err != nil {
+		tm.failTask(ctx, pending, err.Error())
+		return nil
+	}
+	if task.TaskID == 0 {
+		// nothing to respond to on chain, the operator ran it when it answered
+		tm.untrack(pending, true)
+	}
+	return nil
+}
+
+// promotable returns the candidates a task is reassigned among: the ones
+// attesting to it if any is a candidate, all of them otherwise.
+func promotable(candidates []Operator, attesting []Operator) []Operator {
+	var promoted []Operator
+	for _, candidate := range candidates {
+		for _, attester := range attesting {
+			if candidate.Address == attester.Address {
+				promoted = append(promoted, candidate)
+				break
+			}
+		}
+	}
+	if len(promoted) == 0 {
+		return candidates
+	}
+	return promoted
+}
+
+// pickAttesters returns the operators that attest to a task besides the
+// operator it is assigned to: the candidates with the most stake, until they
+// hold thresholdPercentage of total along with the assigned operator and the
+// operators that attested to the task before.
+func pickAttesters(attested []Operator, candidates []Operator, assigned Operator, thresholdPercentage uint32, total *big.Int) []Operator {
+	signing := new(big.Int).Set(stakeOf(assigned))
+	for _, operator := range attested {
+		signing.Add(signing, stakeOf(operator))
+	}
+	needed := new(big.Int).Mul(total, big.NewInt(int64(thresholdPercentage)))
+	meets := func() bool {
+		return new(big.Int).Mul(signing, big.NewInt(100)).Cmp(needed) >= 0
+	}
+
+	candidates = append([]Operator(nil), candidates...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return stakeOf(candidates[i]).Cmp(stakeOf(candidates[j])) > 0
+	})
+	var attesters []Operator
+	for _, candidate := range candidates {
+		if meets() {
+			break
+		}
+		attesters = append(attesters, candidate)
+		signing.Add(signing, stakeOf(candidate))
+	}
+	return attesters
+}
+
+func stakeOf(operator Operator) *big.Int {
+	if operator.Stake == nil {
+		return new(big.Int)
+	}
+	return operator.Stake
+}
+
+func totalStake(operators []Operator) *big.Int {
+	total := new(big.Int)
+	for _, operator := range operators {
+		total.Add(total, stakeOf(operator))
+	}
+	return total
+}
+
+// operatorsByAddress returns the operators whose addresses are in the set.
+func operatorsByAddress(operators []Operator, addresses map[common.Address]bool) []Operator {
+	var found []Operator
+	for _, operator := range operators {
+		if addresses[operator.Address] {
+			found = append(found, operator)
+		}
+	}
+	return found
+}
+
+// sendTask sends the task to the operator. An operator that is busy gets it
+// again after the time it asks for, until that would be past until.
+func sendTask(ctx context.Context, operator Operator, task OperatorTask, until time.Time) error {
+	for {
+		err := sendTaskToOperator(ctx, operator, task)
+		var busy *operatorBusyError
+		if !errors.As(err, &busy) || !time.Now().Add(busy.retryAfter).Before(until) {
+			return err
+		}
+		log.Printf("Operator %s is busy, sending task %d of job %d again in %s", operator.Address.Hex(), task.TaskID, task.JobID, busy.retryAfter)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(busy.retryAfter):
+		}
+	}
+}
+
+func sendTaskToOperator(ctx context.Context, operator Operator, task OperatorTask) error {
+	endpoint, err := operator.executeTaskURL()
+	if err != nil {
+		return err
+	}
+	taskJSON, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(taskJSON))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := operatorClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := defaultOperatorRetryAfter
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return &operatorBusyError{retryAfter: retryAfter}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("failed to send task: " + resp.Status)
+	}
+
+	return nil
+}
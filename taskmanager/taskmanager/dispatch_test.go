@@ -0,0 +1,88 @@
+package taskmanager
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPickAttestersMeetsTheThreshold(t *testing.T) {
+	operators := testOperators(10, 40, 20, 30)
+	total := totalStake(operators)
+
+	// the assigned operator holds 10%, the two with the most stake make it 80%
+	attesters := pickAttesters(nil, operators[1:], operators[0], 67, total)
+	if len(attesters) != 2 || attesters[0].Address != operators[1].Address || attesters[1].Address != operators[3].Address {
+		t.Errorf("expected the operators with 40 and 30 stake to attest, got %v", attesters)
+	}
+
+	// an operator that attested before counts towards the threshold
+	attesters = pickAttesters(operators[1:2], []Operator{operators[2], operators[3]}, operators[0], 67, total)
+	if len(attesters) != 1 || attesters[0].Address != operators[3].Address {
+		t.Errorf("expected the operator with 30 stake to attest, got %v", attesters)
+	}
+
+	if attesters := pickAttesters(nil, operators[:3], operators[3], 30, total); len(attesters) != 0 {
+		t.Errorf("expected no attesters when the assigned operator meets the threshold, got %v", attesters)
+	}
+}
+
+func TestReassignmentPromotesAnAttester(t *testing.T) {
+	tm := testTrackingTaskManager()
+	tm.config.MaxOperatorFailures = 3
+	operators := testOperators(10, 40, 20, 30)
+	// operators[0] was given the task, operators[1] and operators[2] attest to it
+	tried := map[common.Address]bool{operators[0].Address: true}
+	attesting := operators[1:3]
+
+	candidates := tm.eligible(operators, tried)
+	if promoted := promotable(candidates, attesting); len(promoted) != 2 ||
+		promoted[0].Address != operators[1].Address || promoted[1].Address != operators[2].Address {
+		t.Errorf("expected the attesters to take the task over, got %v", promoted)
+	}
+
+	// an attester that keeps failing its tasks is not promoted
+	tm.records[operators[1].Address] = OperatorRecord{ConsecutiveFailures: 3}
+	candidates = tm.eligible(operators, tried)
+	if promoted := promotable(candidates, attesting); len(promoted) != 1 || promoted[0].Address != operators[2].Address {
+		t.Errorf("expected only the healthy attester to take the task over, got %v", promoted)
+	}
+
+	// without an eligible attester, any candidate may take it
+	if promoted := promotable(candidates, operators[1:2]); len(promoted) != len(candidates) {
+		t.Errorf("expected all %d candidates, got %v", len(candidates), promoted)
+	}
+}
+
+func TestSendTaskRetriesABusyOperator(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+	operator := Operator{Socket: server.URL}
+
+	if err := sendTask(context.Background(), operator, OperatorTask{JobID: 1}, time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("expected the task to be sent again once, got %d requests", requests)
+	}
+
+	// an operator that is busy past the deadline is given up on
+	requests = 0
+	if err := sendTask(context.Background(), operator, OperatorTask{JobID: 1}, time.Now().Add(time.Second/2)); err == nil {
+		t.Error("expected an error for an operator that is busy until after the deadline")
+	}
+	if requests != 1 {
+		t.Errorf("expected one request, got %d", requests)
+	}
+}
@@ -0,0 +1,264 @@
+package taskmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"taskmanager/eventtrigger"
+	"taskmanager/logstream"
+	"taskmanager/reorg"
+	"taskmanager/scheduler"
+)
+
+// eventWatch is an event-triggered job whose event is followed.
+type eventWatch struct {
+	jobID   uint32
+	trigger *eventtrigger.Trigger
+	// fromBlock is the first block whose logs run the job
+	fromBlock uint64
+	cursor    *cursor
+}
+
+// eventJobs follows the events of all the event-triggered jobs over one log
+// stream and confirmer, apart from the stream of the Keeper contracts, and
+// runs each job for the confirmed logs its trigger matches. The stream is
+// started again whenever a job comes or goes, from the first block a job has
+// not run for; every job has its own cursor, so a restarted stream or task
+// manager resumes each job right after the last log it ran for.
+type eventJobs struct {
+	tm     *TaskManager
+	client logstream.Client
+	// run runs the job for a log, and reports whether the job still runs
+	run func(jobID uint32, trigger *eventtrigger.Trigger, vLog types.Log) bool
+
+	mu      sync.Mutex
+	ctx     context.Context
+	watches map[uint32]*eventWatch
+	// stop stops the current stream
+	stop context.CancelFunc
+}
+
+func newEventJobs(tm *TaskManager, client logstream.Client) *eventJobs {
+	return &eventJobs{
+		tm:      tm,
+		client:  client,
+		run:     tm.runJobEvent,
+		watches: make(map[uint32]*eventWatch),
+	}
+}
+
+// add follows the event of the job, in place of the one it was following.
+func (e *eventJobs) add(ctx context.Context, watch *eventWatch) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.ctx = ctx
+	e.watches[watch.jobID] = watch
+	e.restart()
+}
+
+// remove stops following the event of the job.
+func (e *eventJobs) remove(jobID uint32) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.watches[jobID]; !ok {
+		return
+	}
+	delete(e.watches, jobID)
+	e.restart()
+}
+
+// restart replaces the stream with one for the current jobs. e.mu must be held.
+func (e *eventJobs) restart() {
+	if e.stop != nil {
+		e.stop()
+		e.stop = nil
+	}
+	if len(e.watches) == 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(e.ctx)
+	e.stop = cancel
+
+	var addresses []common.Address
+	var events []common.Hash
+	from := uint64(0)
+	first := true
+	for _, watch := range e.watches {
+		if !slices.Contains(addresses, watch.trigger.Address) {
+			addresses = append(addresses, watch.trigger.Address)
+		}
+		if !slices.Contains(events, watch.trigger.Event.ID) {
+			events = append(events, watch.trigger.Event.ID)
+		}
+		resume := max(watch.fromBlock, watch.cursor.resumeBlock(watch.fromBlock))
+		if first || resume < from {
+			from, first = resume, false
+		}
+	}
+
+	confirmer := reorg.NewConfirmer[struct{}](e.tm.confirmationDepth, e.client)
+	// the indexed arguments each job filters on are matched by its trigger
+	query := ethereum.FilterQuery{Addresses: addresses, Topics: [][]common.Hash{events}}
+	// only the stream of the Keeper contracts is reported
+	stream := e.tm.newLogStream(e.client, query, NewMetrics(prometheus.NewRegistry()))
+	stream.OnHead = func(_ context.Context, head *types.Header) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if ctx.Err() != nil {
+			// the stream was replaced
+			return
+		}
+		update, err := confirmer.AddHead(ctx, head)
+		if err != nil {
+			log.Printf("Failed to track block %d for event jobs: %v", head.Number, err)
+			return
+		}
+		e.handle(stream, update)
+	}
+	stream.OnLog = func(_ context.Context, vLog types.Log) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		if vLog.Removed || e.unprocessed(vLog) {
+			e.handle(stream, confirmer.AddLog(vLog, struct{}{}))
+		}
+	}
+	log.Printf("Following the events of %d jobs from block %d", len(e.watches), from)
+	go stream.Run(ctx, from)
+}
+
+// handle runs the jobs for the confirmed logs of the update. e.mu must be held.
+func (e *eventJobs) handle(stream *logstream.Stream, update reorg.Update[struct{}]) {
+	if update.Reorged {
+		// a task that was sent for a removed log cannot be taken back
+		for _, watch := range e.watches {
+			if err := watch.cursor.rewind(update.ForkPoint); err != nil {
+				log.Printf("Failed to rewind the event cursor of job %d to block %d: %v", watch.jobID, update.ForkPoint, err)
+			}
+		}
+		stream.Rewind(update.ForkPoint + 1)
+	}
+	ended := false
+	for _, event := range update.Confirmed {
+		vLog := event.Log
+		for _, watch := range e.watches {
+			if !watch.follows(vLog) {
+				continue
+			}
+			if !e.run(watch.jobID, watch.trigger, vLog) {
+				delete(e.watches, watch.jobID)
+				ended = true
+				continue
+			}
+			if err := watch.cursor.advance(vLog); err != nil {
+				log.Printf("Failed to save the event cursor of job %d at block %d log %d: %v", watch.jobID, vLog.BlockNumber, vLog.Index, err)
+			}
+		}
+	}
+	if ended {
+		e.restart()
+	}
+}
+
+// unprocessed reports whether a job has yet to run for the log. e.mu must be held.
+func (e *eventJobs) unprocessed(vLog types.Log) bool {
+	for _, watch := range e.watches {
+		if watch.follows(vLog) {
+			return true
+		}
+	}
+	return false
+}
+
+// follows reports whether the job is to run for the log.
+func (w *eventWatch) follows(vLog types.Log) bool {
+	return w.trigger.Matches(vLog) && vLog.BlockNumber >= w.fromBlock && !w.cursor.processed(vLog)
+}
+
+// watchJobEvent follows the logs of an event-triggered job's event, and runs
+// the job for every one of them once it is confirmed (see eventJobs).
+func (tm *TaskManager) watchJobEvent(ctx context.Context, schedule scheduler.Schedule) {
+	jobID := schedule.JobID
+	task, err := decodeScheduledTask(schedule)
+	if err != nil {
+		log.Printf("Failed to decode task of job %d: %v", jobID, err)
+		return
+	}
+	trigger, err := eventtrigger.Parse(task.JobDescription)
+	if err == nil && trigger == nil {
+		err = errors.New("the job declares no event")
+	}
+	if err != nil {
+		log.Printf("Failed to read the event of job %d: %v", jobID, err)
+		return
+	}
+	if err := os.MkdirAll(tm.config.EventCursorsDir, 0o755); err != nil {
+		log.Printf("Failed to create %s: %v", tm.config.EventCursorsDir, err)
+		return
+	}
+	cursor, err := loadCursor(tm.eventCursorPath(jobID))
+	if err != nil {
+		log.Printf("Failed to load the event cursor of job %d: %v", jobID, err)
+		return
+	}
+	log.Printf("Following %s events of %s for job %d", trigger.Event.Name, trigger.Address.Hex(), jobID)
+	tm.eventJobs.add(ctx, &eventWatch{jobID: jobID, trigger: trigger, fromBlock: task.FromBlock, cursor: cursor})
+}
+
+// runJobEvent runs the job for a confirmed log of its event, with the decoded
+// event as the job's input. It reports false once the job is past its timeframe.
+func (tm *TaskManager) runJobEvent(jobID uint32, trigger *eventtrigger.Trigger, vLog types.Log) bool {
+	event, err := trigger.Decode(vLog)
+	if err != nil {
+		log.Printf("Skipping log of job %d: %v", jobID, err)
+		return true
+	}
+	schedule, ok, err := tm.scheduler.Fire(jobID)
+	if err != nil {
+		log.Printf("Failed to save schedules after a run of job %d: %v", jobID, err)
+	}
+	if !ok {
+		log.Printf("Job %d is past its timeframe, no longer following its events", jobID)
+		return false
+	}
+	task, err := decodeScheduledTask(schedule)
+	if err != nil {
+		log.Printf("Failed to decode task of job %d: %v", jobID, err)
+		return true
+	}
+	task.Args = map[string]interface{}{"event": event}
+	log.Printf("Running job %d for %s in block %d, tx %s", jobID, event.Name, event.BlockNumber, event.TransactionHash.Hex())
+	// creating the task waits for a transaction, which must not hold up the stream
+	go tm.runTask(context.Background(), schedule, task)
+	return true
+}
+
+// stopEventWatch stops following the event of the job. Its cursor is kept, so
+// a job that is scheduled again resumes after the last log it ran for.
+func (tm *TaskManager) stopEventWatch(jobID uint32) {
+	tm.eventJobs.remove(jobID)
+}
+
+// deleteEventCursor forgets how far the event of a deleted job was followed.
+func (tm *TaskManager) deleteEventCursor(jobID uint32) {
+	if err := os.Remove(tm.eventCursorPath(jobID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to remove the event cursor of job %d: %v", jobID, err)
+	}
+}
+
+func (tm *TaskManager) eventCursorPath(jobID uint32) string {
+	return filepath.Join(tm.config.EventCursorsDir, fmt.Sprintf("job-%d.json", jobID))
+}
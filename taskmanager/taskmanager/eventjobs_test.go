@@ -0,0 +1,156 @@
+package taskmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"taskmanager/eventtrigger"
+)
+
+// forkingChainClient serves a chain of linked headers, and the logs of its blocks, over http only.
+type forkingChainClient struct {
+	headers []*types.Header
+	logs    []types.Log
+}
+
+// extend adds blocks on top of block parent, replacing the blocks after it. The
+// seed makes the new blocks differ from the ones they replace.
+func (c *forkingChainClient) extend(parent uint64, head uint64, seed uint64) {
+	c.headers = c.headers[:parent+1]
+	for n := parent + 1; n <= head; n++ {
+		c.headers = append(c.headers, &types.Header{
+			Number:     new(big.Int).SetUint64(n),
+			ParentHash: c.headers[n-1].Hash(),
+			Extra:      []byte{byte(seed)},
+		})
+	}
+	var logs []types.Log
+	for _, vLog := range c.logs {
+		if vLog.BlockNumber <= parent {
+			logs = append(logs, vLog)
+		}
+	}
+	c.logs = logs
+}
+
+func (c *forkingChainClient) addLog(blockNumber uint64, index uint) types.Log {
+	vLog := testLog(blockNumber, index)
+	vLog.BlockHash = c.headers[blockNumber].Hash()
+	c.logs = append(c.logs, vLog)
+	return vLog
+}
+
+func (c *forkingChainClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *forkingChainClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+	var logs []types.Log
+	for _, vLog := range c.logs {
+		if vLog.BlockNumber >= from && vLog.BlockNumber <= to {
+			logs = append(logs, vLog)
+		}
+	}
+	return logs, nil
+}
+
+func (c *forkingChainClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("websocket unavailable")
+}
+
+func (c *forkingChainClient) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return nil, errors.New("websocket unavailable")
+}
+
+type jobRun struct {
+	jobID       uint32
+	blockNumber uint64
+}
+
+func TestEventJobsShareOneStream(t *testing.T) {
+	swapTrigger, err := eventtrigger.Parse("event: 0x00000000000000000000000000000000000000aa Swap(address indexed sender, uint256 amount)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	syncTrigger, err := eventtrigger.Parse("event: 0x00000000000000000000000000000000000000bb Sync(uint256 reserve)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := &forkingChainClient{headers: []*types.Header{{Number: big.NewInt(0)}}}
+	chain.extend(0, 6, 0)
+	addEvent := func(blockNumber uint64, address common.Address, topics ...common.Hash) {
+		chain.addLog(blockNumber, 0)
+		vLog := &chain.logs[len(chain.logs)-1]
+		vLog.Address, vLog.Topics = address, topics
+	}
+	addEvent(2, swapTrigger.Address, swapTrigger.Event.ID, common.Hash{1})
+	addEvent(3, syncTrigger.Address, syncTrigger.Event.ID)
+	// an event of another contract, and one from before the job's first block
+	addEvent(4, common.HexToAddress("0xcc"), swapTrigger.Event.ID, common.Hash{1})
+	addEvent(1, syncTrigger.Address, syncTrigger.Event.ID)
+
+	tm := &TaskManager{config: Config{
+		PollInterval:     10 * time.Millisecond,
+		BackfillPageSize: 10,
+		EventCursorsDir:  t.TempDir(),
+	}}
+	tm.eventJobs = newEventJobs(tm, chain)
+	var runs []jobRun
+	tm.eventJobs.run = func(jobID uint32, _ *eventtrigger.Trigger, vLog types.Log) bool {
+		runs = append(runs, jobRun{jobID, vLog.BlockNumber})
+		return true
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for jobID, watch := range map[uint32]struct {
+		trigger   *eventtrigger.Trigger
+		fromBlock uint64
+	}{1: {swapTrigger, 0}, 2: {syncTrigger, 2}} {
+		cursor, err := loadCursor(tm.eventCursorPath(jobID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tm.eventJobs.add(ctx, &eventWatch{jobID: jobID, trigger: watch.trigger, fromBlock: watch.fromBlock, cursor: cursor})
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		tm.eventJobs.mu.Lock()
+		done := len(runs) >= 2
+		tm.eventJobs.mu.Unlock()
+		if done || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// the stream keeps polling, so give it a chance to run a job twice
+	time.Sleep(50 * time.Millisecond)
+	tm.eventJobs.mu.Lock()
+	got := append([]jobRun(nil), runs...)
+	tm.eventJobs.mu.Unlock()
+	if len(got) != 2 || got[0] != (jobRun{1, 2}) || got[1] != (jobRun{2, 3}) {
+		t.Fatalf("runs = %v, want job 1 for block 2 and job 2 for block 3", got)
+	}
+
+	// a stopped job keeps its cursor, and a deleted one does not
+	tm.stopEventWatch(1)
+	if _, err := os.Stat(tm.eventCursorPath(1)); err != nil {
+		t.Errorf("cursor of a stopped job: %v", err)
+	}
+	tm.deleteEventCursor(1)
+	if _, err := os.Stat(tm.eventCursorPath(1)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("cursor of a deleted job: %v, want it removed", err)
+	}
+}
@@ -0,0 +1,112 @@
+package taskmanager
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	jobmanager "taskmanager/bindings/KeeperNetworkJobManager"
+	taskmanagerbinding "taskmanager/bindings/KeeperNetworkTaskManager"
+)
+
+var (
+	ErrUnknownEvent = errors.New("log is not a known Keeper contract event")
+	errNoTopics     = errors.New("log has no topics")
+)
+
+type logParser func(types.Log) (interface{}, error)
+
+// logDecoder decodes the JobManager and TaskManager events through their
+// generated bindings, which check the event signature and the bounds of
+// every field, so malformed logs are rejected instead of misread.
+type logDecoder struct {
+	parsers map[common.Address]map[common.Hash]logParser
+}
+
+func newLogDecoder(jobManagerAddr, taskManagerAddr common.Address) (*logDecoder, error) {
+	jobManagerFilterer, err := jobmanager.NewContractKeeperNetworkJobManagerFilterer(jobManagerAddr, nil)
+	if err != nil {
+		return nil, err
+	}
+	taskManagerFilterer, err := taskmanagerbinding.NewContractKeeperNetworkTaskManagerFilterer(taskManagerAddr, nil)
+	if err != nil {
+		return nil, err
+	}
+	jobManagerParsers, err := eventParsers(jobmanager.ContractKeeperNetworkJobManagerMetaData.GetAbi, map[string]logParser{
+		"JobCreated":       parser(jobManagerFilterer.ParseJobCreated),
+		"JobDeleted":       parser(jobManagerFilterer.ParseJobDeleted),
+		"JobStatusUpdated": parser(jobManagerFilterer.ParseJobStatusUpdated),
+	})
+	if err != nil {
+		return nil, err
+	}
+	taskManagerParsers, err := eventParsers(taskmanagerbinding.ContractKeeperNetworkTaskManagerMetaData.GetAbi, map[string]logParser{
+		"TaskCreated":                parser(taskManagerFilterer.ParseTaskCreated),
+		"TaskDeleted":                parser(taskManagerFilterer.ParseTaskDeleted),
+		"TaskStatusUpdated":          parser(taskManagerFilterer.ParseTaskStatusUpdated),
+		"TaskAssigned":               parser(taskManagerFilterer.ParseTaskAssigned),
+		"TaskCompleted":              parser(taskManagerFilterer.ParseTaskCompleted),
+		"TaskResponded":              parser(taskManagerFilterer.ParseTaskResponded),
+		"TaskChallengedSuccessfully": parser(taskManagerFilterer.ParseTaskChallengedSuccessfully),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &logDecoder{parsers: map[common.Address]map[common.Hash]logParser{
+		jobManagerAddr:  jobManagerParsers,
+		taskManagerAddr: taskManagerParsers,
+	}}, nil
+}
+
+// decode returns the binding's event struct for the log, e.g. a
+// *jobmanager.ContractKeeperNetworkJobManagerJobCreated.
+func (d *logDecoder) decode(vLog types.Log) (interface{}, error) {
+	if len(vLog.Topics) == 0 {
+		return nil, errNoTopics
+	}
+	parse, ok := d.parsers[vLog.Address][vLog.Topics[0]]
+	if !ok {
+		return nil, fmt.Errorf("%w: address %s, topic %s", ErrUnknownEvent, vLog.Address.Hex(), vLog.Topics[0].Hex())
+	}
+	event, err := parse(vLog)
+	if err != nil {
+		return nil, fmt.Errorf("malformed log in tx %s: %w", vLog.TxHash.Hex(), err)
+	}
+	return event, nil
+}
+
+// topics returns the event signatures the decoder knows, for filtering logs.
+func (d *logDecoder) topics() []common.Hash {
+	var topics []common.Hash
+	for _, parsers := range d.parsers {
+		for topic := range parsers {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+func eventParsers(getAbi func() (*abi.ABI, error), parsersByName map[string]logParser) (map[common.Hash]logParser, error) {
+	contractAbi, err := getAbi()
+	if err != nil {
+		return nil, err
+	}
+	parsers := make(map[common.Hash]logParser, len(parsersByName))
+	for name, parse := range parsersByName {
+		event, ok := contractAbi.Events[name]
+		if !ok {
+			return nil, fmt.Errorf("event %s is not in the contract abi", name)
+		}
+		parsers[event.ID] = parse
+	}
+	return parsers, nil
+}
+
+func parser[T any](parse func(types.Log) (*T, error)) logParser {
+	return func(vLog types.Log) (interface{}, error) {
+		return parse(vLog)
+	}
+}
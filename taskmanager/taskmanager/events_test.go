@@ -0,0 +1,89 @@
+package taskmanager
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	jobmanager "taskmanager/bindings/KeeperNetworkJobManager"
+)
+
+var (
+	testJobManagerAddr  = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testTaskManagerAddr = common.HexToAddress("0x2000000000000000000000000000000000000002")
+)
+
+func jobCreatedLog(t *testing.T, jobID uint32, jobType, gitlink string) types.Log {
+	t.Helper()
+	jobManagerAbi, err := jobmanager.ContractKeeperNetworkJobManagerMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := jobManagerAbi.Events["JobCreated"]
+	data, err := event.Inputs.NonIndexed().Pack(jobType, gitlink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.Log{
+		Address: testJobManagerAddr,
+		Topics:  []common.Hash{event.ID, common.BigToHash(new(big.Int).SetUint64(uint64(jobID)))},
+		Data:    data,
+	}
+}
+
+func TestDecodeJobCreated(t *testing.T) {
+	decoder, err := newLogDecoder(testJobManagerAddr, testTaskManagerAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := decoder.decode(jobCreatedLog(t, 42, "js", "https://example.com/job.js"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobCreated, ok := event.(*jobmanager.ContractKeeperNetworkJobManagerJobCreated)
+	if !ok {
+		t.Fatalf("expected a JobCreated event, got %T", event)
+	}
+	if jobCreated.JobId != 42 || jobCreated.JobType != "js" || jobCreated.Gitlink != "https://example.com/job.js" {
+		t.Errorf("unexpected event %+v", jobCreated)
+	}
+}
+
+func TestDecodeRejectsMalformedLogs(t *testing.T) {
+	decoder, err := newLogDecoder(testJobManagerAddr, testTaskManagerAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := jobCreatedLog(t, 1, "js", "https://example.com/job.js")
+
+	truncated := valid
+	truncated.Data = valid.Data[:len(valid.Data)-40]
+
+	badOffset := valid
+	badOffset.Data = append([]byte(nil), valid.Data...)
+	badOffset.Data[31] = 0xff
+
+	missingTopic := valid
+	missingTopic.Topics = valid.Topics[:1]
+
+	otherContract := valid
+	otherContract.Address = testTaskManagerAddr
+
+	for name, vLog := range map[string]types.Log{
+		"truncated":      truncated,
+		"bad offset":     badOffset,
+		"missing topic":  missingTopic,
+		"other contract": otherContract,
+		"no topics":      {Address: testJobManagerAddr},
+	} {
+		if _, err := decoder.decode(vLog); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := decoder.decode(otherContract); !errors.Is(err, ErrUnknownEvent) {
+		t.Errorf("expected ErrUnknownEvent for an event from the wrong contract, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package taskmanager
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"taskmanager/logstream"
+)
+
+const metricsNamespace = "taskmanager"
+
+type Metrics struct {
+	subscriptionTransport  *prometheus.GaugeVec
+	subscriptionFailures   prometheus.Counter
+	subscriptionReconnects prometheus.Counter
+	logsFetched            prometheus.Counter
+}
+
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	return &Metrics{
+		subscriptionTransport: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "subscription_transport",
+				Help:      "1 for the transport the task manager currently follows the chain with, 0 for the others",
+			},
+			[]string{"transport"},
+		),
+		subscriptionFailures: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "subscription_failures",
+				Help:      "The number of times subscribing over websocket failed or a subscription broke",
+			}),
+		subscriptionReconnects: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "subscription_reconnects",
+				Help:      "The number of times the websocket subscriptions came back after failing",
+			}),
+		logsFetched: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "logs_fetched",
+				Help:      "The number of logs fetched with eth_getLogs while backfilling, polling or filling the gap after a reconnect",
+			}),
+	}
+}
+
+// Transport sets the transport the task manager follows the chain with, see logstream.Metrics.
+func (m *Metrics) Transport(transport string) {
+	for _, t := range []string{logstream.TransportWebsocket, logstream.TransportPolling} {
+		value := 0.0
+		if t == transport {
+			value = 1
+		}
+		m.subscriptionTransport.WithLabelValues(t).Set(value)
+	}
+}
+
+func (m *Metrics) SubscriptionFailed() {
+	m.subscriptionFailures.Inc()
+}
+
+func (m *Metrics) SubscriptionReconnected() {
+	m.subscriptionReconnects.Inc()
+}
+
+func (m *Metrics) LogsFetched(count int) {
+	m.logsFetched.Add(float64(count))
+}
@@ -0,0 +1,168 @@
+package taskmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	opstateretriever "github.com/Layr-Labs/eigensdk-go/contracts/bindings/OperatorStateRetriever"
+	regcoord "github.com/Layr-Labs/eigensdk-go/contracts/bindings/RegistryCoordinator"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	servicemanager "taskmanager/bindings/KeeperNetworkServiceManager"
+	taskmanagerbinding "taskmanager/bindings/KeeperNetworkTaskManager"
+)
+
+// Operator is an operator registered in the quorums of a job, as it was at a block.
+type Operator struct {
+	Address common.Address
+	ID      [32]byte
+	// Stake is the operator's stake summed over the job's quorums.
+	Stake *big.Int
+	// Socket is the address the operator advertised when registering, where its keeper listens for tasks.
+	Socket string
+}
+
+// executeTaskURL returns the url of the operator's /executeTask endpoint. Sockets
+// are usually "host:port", but may carry a scheme.
+func (o Operator) executeTaskURL() (string, error) {
+	socket := o.Socket
+	if !strings.Contains(socket, "://") {
+		socket = "http://" + socket
+	}
+	u, err := url.Parse(socket)
+	if err != nil {
+		return "", fmt.Errorf("invalid socket %q of operator %s: %w", o.Socket, o.Address.Hex(), err)
+	}
+	return u.JoinPath("executeTask").String(), nil
+}
+
+// operatorRegistry reads the operators of a set of quorums from the
+// OperatorStateRetriever, and their sockets from the OperatorSocketUpdate
+// events of the registry coordinator.
+type operatorRegistry struct {
+	registryCoordinatorAddr common.Address
+	stateRetriever          *opstateretriever.ContractOperatorStateRetrieverCaller
+	registryCoordinator     *regcoord.ContractRegistryCoordinatorFilterer
+	serviceManager          *servicemanager.ContractKeeperNetworkServiceManagerCaller
+	pageSize                uint64
+
+	mu      sync.Mutex
+	sockets map[[32]byte]string
+	// next block to read socket updates from
+	socketsBlock uint64
+}
+
+// newOperatorRegistry reads the operators registered with the registry coordinator of the task
+// manager. Socket updates are read from startBlock on, which must be at or before the deployment
+// of the registry coordinator for every socket to be known.
+func newOperatorRegistry(
+	client *ethclient.Client,
+	serviceManager *servicemanager.ContractKeeperNetworkServiceManagerCaller,
+	taskManager *taskmanagerbinding.ContractKeeperNetworkTaskManager,
+	stateRetrieverAddr common.Address,
+	startBlock, pageSize uint64,
+) (*operatorRegistry, error) {
+	registryCoordinatorAddr, err := taskManager.RegistryCoordinator(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registry coordinator address: %w", err)
+	}
+	stateRetriever, err := opstateretriever.NewContractOperatorStateRetrieverCaller(stateRetrieverAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	registryCoordinator, err := regcoord.NewContractRegistryCoordinatorFilterer(registryCoordinatorAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	return &operatorRegistry{
+		registryCoordinatorAddr: registryCoordinatorAddr,
+		stateRetriever:          stateRetriever,
+		registryCoordinator:     registryCoordinator,
+		serviceManager:          serviceManager,
+		pageSize:                pageSize,
+		sockets:                 make(map[[32]byte]string),
+		socketsBlock:            startBlock,
+	}, nil
+}
+
+// operators returns the operators registered in the quorums at the block,
+// sorted by address. Operators that are frozen or did not advertise a
+// socket cannot be given tasks and are left out.
+func (r *operatorRegistry) operators(ctx context.Context, quorumNumbers []byte, blockNumber uint64) ([]Operator, error) {
+	if len(quorumNumbers) == 0 {
+		return nil, fmt.Errorf("no quorums")
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(blockNumber)}
+	state, err := r.stateRetriever.GetOperatorState(opts, r.registryCoordinatorAddr, quorumNumbers, uint32(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state: %w", err)
+	}
+	if err := r.updateSockets(ctx, blockNumber); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[[32]byte]*Operator)
+	for _, quorum := range state {
+		for _, registered := range quorum {
+			operator, ok := byID[registered.OperatorId]
+			if !ok {
+				operator = &Operator{Address: registered.Operator, ID: registered.OperatorId, Stake: new(big.Int)}
+				byID[registered.OperatorId] = operator
+			}
+			operator.Stake.Add(operator.Stake, registered.Stake)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	operators := make([]Operator, 0, len(byID))
+	for id, operator := range byID {
+		operator.Socket = r.sockets[id]
+		if operator.Socket == "" {
+			log.Printf("Operator %s has no socket, not giving it tasks", operator.Address.Hex())
+			continue
+		}
+		frozen, err := r.serviceManager.FrozenOperators(opts, operator.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check whether operator %s is frozen: %w", operator.Address.Hex(), err)
+		}
+		if frozen {
+			continue
+		}
+		operators = append(operators, *operator)
+	}
+	sort.Slice(operators, func(i, j int) bool {
+		return operators[i].Address.Cmp(operators[j].Address) < 0
+	})
+	return operators, nil
+}
+
+// updateSockets reads the socket updates up to the block, from where the last call stopped.
+func (r *operatorRegistry) updateSockets(ctx context.Context, toBlock uint64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for r.socketsBlock <= toBlock {
+		end := min(r.socketsBlock+r.pageSize-1, toBlock)
+		it, err := r.registryCoordinator.FilterOperatorSocketUpdate(&bind.FilterOpts{Start: r.socketsBlock, End: &end, Context: ctx}, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get operator sockets of blocks %d to %d: %w", r.socketsBlock, end, err)
+		}
+		for it.Next() {
+			r.sockets[it.Event.OperatorId] = it.Event.Socket
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return fmt.Errorf("failed to get operator sockets of blocks %d to %d: %w", r.socketsBlock, end, err)
+		}
+		r.socketsBlock = end + 1
+	}
+	return nil
+}
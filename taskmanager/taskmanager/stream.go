@@ -0,0 +1,47 @@
+package taskmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"taskmanager/logstream"
+)
+
+// newLogStream follows the logs matching query, over websocket when ClientURL is set, and
+// polls for them with client while it cannot subscribe.
+func (tm *TaskManager) newLogStream(client logstream.Client, query ethereum.FilterQuery, metrics *Metrics) *logstream.Stream {
+	stream := &logstream.Stream{
+		Query:        query,
+		HTTPClient:   client,
+		PageSize:     tm.config.BackfillPageSize,
+		PollInterval: tm.config.PollInterval,
+		Metrics:      metrics,
+		Logger:       stdLogger{},
+	}
+	if url := tm.config.ClientURL; url != "" {
+		stream.Dial = func(ctx context.Context) (logstream.Client, error) {
+			return ethclient.DialContext(ctx, url)
+		}
+	}
+	return stream
+}
+
+// stdLogger logs what happens to the streams with the standard logger, like the rest of the task manager.
+type stdLogger struct{}
+
+func (stdLogger) Info(msg string, tags ...any) { logTagged(msg, tags) }
+func (stdLogger) Warn(msg string, tags ...any) { logTagged(msg, tags) }
+
+func logTagged(msg string, tags []any) {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i+1 < len(tags); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", tags[i], tags[i+1])
+	}
+	log.Print(b.String())
+}
@@ -2,194 +2,596 @@ package taskmanager
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
 	"log"
 	"math/big"
-	"net/http"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/robfig/cron"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/prometheus/client_golang/prometheus"
+
+	jobmanager "taskmanager/bindings/KeeperNetworkJobManager"
+	servicemanager "taskmanager/bindings/KeeperNetworkServiceManager"
+	taskmanagerbinding "taskmanager/bindings/KeeperNetworkTaskManager"
+	"taskmanager/eventtrigger"
+	"taskmanager/logstream"
+	"taskmanager/notify"
+	"taskmanager/reorg"
+	"taskmanager/scheduler"
 )
 
-const JobCreatedEventSignature = "JobCreated(uint32,string,string,string)"
+const (
+	defaultBackfillPageSize = 2000
+	defaultEventCursorsDir  = "taskmanager-event-cursors"
+)
+
+type Config struct {
+	// ClientURL is the websocket url of the node, which the task manager subscribes to logs through.
+	ClientURL string
+	// HTTPClientURL is the http url of the node, which the task manager calls the contracts
+	// through and polls for logs through while the websocket subscriptions are down.
+	HTTPClientURL string
+	// PollInterval is how often logs are polled for while the websocket subscriptions are down.
+	PollInterval time.Duration
+	// ServiceManagerAddr is the KeeperNetworkServiceManager, which knows the
+	// job and task manager addresses.
+	ServiceManagerAddr string
+	// JobManagerAddr and TaskManagerAddr, if set, must be the addresses the
+	// service manager knows, which catches a config for the wrong deployment.
+	JobManagerAddr  string
+	TaskManagerAddr string
+	// OperatorStateRetrieverAddr is the OperatorStateRetriever the operators of a job's quorums are read from.
+	OperatorStateRetrieverAddr string
+	// PrivateKey signs the transactions that create and assign tasks. Without
+	// it, tasks are sent to operators without being recorded on chain.
+	PrivateKey *ecdsa.PrivateKey
+	// TaskTimeout is how long an operator has to respond to a task before it is
+	// given to another operator. It is cut short by the end of the job's timeframe.
+	TaskTimeout time.Duration
+	// MaxTaskAttempts is how many operators a task is given to before it is given up on.
+	MaxTaskAttempts int
+	// MaxOperatorFailures is how many tasks in a row an operator may fail before
+	// it is only given tasks that no other operator is left for.
+	MaxOperatorFailures int
+	// AssignmentPolicy is the name of the policy that picks the operator of a task, see NewAssignmentPolicy.
+	AssignmentPolicy string
+	// CursorPath is where the position of the last processed log is kept between runs.
+	CursorPath string
+	// SchedulesPath is where the schedules of the jobs are kept between runs.
+	SchedulesPath string
+	// EventCursorsDir is where the position of the last processed log of each
+	// event-triggered job is kept between runs.
+	EventCursorsDir string
+	// StartBlock is where the backfill starts when there is no cursor yet.
+	StartBlock uint64
+	// BackfillPageSize is the number of blocks fetched per eth_getLogs call while backfilling.
+	BackfillPageSize uint64
+	// OperatorSocketsStartBlock is the block the operators' socket updates are read from. It
+	// must be at or before the registry coordinator deployment, and defaults to StartBlock.
+	OperatorSocketsStartBlock uint64
+	// OperatorSocketsPageSize is the number of blocks fetched per eth_getLogs call while reading
+	// socket updates. It defaults to BackfillPageSize.
+	OperatorSocketsPageSize uint64
+	// ConfirmationDepths is the number of blocks that must be built on top of an event's
+	// block before it is acted on, by chain id. Chains that are not listed use 0.
+	ConfirmationDepths map[uint64]uint64
+	// Notifier is told about the lifecycle of the jobs. It defaults to notify.Nop.
+	Notifier notify.Notifier
+	// Registry is where the task manager's metrics are registered.
+	Registry prometheus.Registerer
+}
 
 type TaskManager struct {
-	client        *ethclient.Client
-	contractAddr  common.Address
-	jobCreatedSig common.Hash
+	config          Config
+	client          *ethclient.Client
+	cursor          *cursor
+	jobManagerAddr  common.Address
+	taskManagerAddr common.Address
+	jobManager      *jobmanager.ContractKeeperNetworkJobManagerCaller
+	// writer is nil when there is no key to send transactions with
+	writer    TaskWriter
+	operators *operatorRegistry
+	policy    AssignmentPolicy
+	pendingMu sync.Mutex
+	pending   map[uint32]*pendingTask
+	loads     map[common.Address]int
+	records   map[common.Address]OperatorRecord
+	// responded are the tasks recently completed, by task id, see retrackTask
+	responded map[uint32]respondedTask
+	decoder   *logDecoder
+	confirmer *reorg.Confirmer[struct{}]
+	// confirmationDepth is the depth logs are acted on at, see Config.ConfirmationDepths
+	confirmationDepth uint64
+	scheduler         *scheduler.Scheduler
+	// eventJobs follows the events of the event-triggered jobs
+	eventJobs *eventJobs
+	metrics   *Metrics
 }
 
-type Task struct {
-	TaskID   uint32 `json:"taskID"`
-	TaskType string `json:"taskType"`
+// Job is a job as stored by KeeperNetworkJobManager.jobs.
+type Job struct {
+	JobID                     uint32
+	JobType                   string
+	JobDescription            string
+	JobURL                    string
+	Status                    string
+	QuorumNumbers             []byte
+	QuorumThresholdPercentage uint32
+	// Timeframe is the number of seconds after its creation the job runs for.
+	// A zero timeframe does not bound it.
+	Timeframe   uint32
+	BlockNumber uint64
 }
 
-type JobCreatedEvent struct {
+// OperatorTask is the body of the keeper's /executeTask request.
+type OperatorTask struct {
 	JobID          uint32 `json:"jobID"`
+	TaskID         uint32 `json:"taskID"`
 	JobType        string `json:"jobType"`
 	JobDescription string `json:"jobDescription"`
 	JobURL         string `json:"jobURL"`
-	Tasks          []Task `json:"tasks"`
-	Timeframe      uint32 `json:"timeframe"`
+	// Args is passed to the job as its input. Event-triggered jobs get the
+	// event that triggered them under "event", see eventtrigger.Event.
+	Args map[string]interface{} `json:"args,omitempty"`
+	// BlockNumber is the head when the task was created. Operators evaluate
+	// the job's check from it on, so that they all evaluate it at the same
+	// blocks.
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+	// SubmitUpkeep tells the operator the task is assigned to that it submits
+	// the call an upkeep job returns. Other operators only attest to it.
+	SubmitUpkeep bool `json:"submitUpkeep,omitempty"`
 }
 
-func NewTaskManager(clientURL string, contractAddr string) (*TaskManager, error) {
-	client, err := ethclient.Dial(clientURL)
+// NewTaskManager connects to the node and looks up the job and task manager
+// contracts of the Keeper service manager.
+func NewTaskManager(config Config) (*TaskManager, error) {
+	if config.BackfillPageSize == 0 {
+		config.BackfillPageSize = defaultBackfillPageSize
+	}
+	if config.OperatorSocketsStartBlock == 0 {
+		config.OperatorSocketsStartBlock = config.StartBlock
+	}
+	if config.OperatorSocketsPageSize == 0 {
+		config.OperatorSocketsPageSize = config.BackfillPageSize
+	}
+	if config.EventCursorsDir == "" {
+		config.EventCursorsDir = defaultEventCursorsDir
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = logstream.DefaultPollInterval
+	}
+	if config.TaskTimeout == 0 {
+		config.TaskTimeout = defaultTaskTimeout
+	}
+	if config.MaxTaskAttempts == 0 {
+		config.MaxTaskAttempts = defaultMaxTaskAttempts
+	}
+	if config.MaxOperatorFailures == 0 {
+		config.MaxOperatorFailures = defaultMaxOperatorFailures
+	}
+	if config.AssignmentPolicy == "" {
+		config.AssignmentPolicy = AssignmentStakeWeighted
+	}
+	policy, err := NewAssignmentPolicy(config.AssignmentPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if config.Notifier == nil {
+		config.Notifier = notify.Nop{}
+	}
+	if config.Registry == nil {
+		config.Registry = prometheus.NewRegistry()
+	}
+	cursor, err := loadCursor(config.CursorPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cursor from %s: %w", config.CursorPath, err)
+	}
+	client, err := ethclient.Dial(config.HTTPClientURL)
 	if err != nil {
 		return nil, err
 	}
-	jobCreatedSig := crypto.Keccak256Hash([]byte(JobCreatedEventSignature))
-	return &TaskManager{
-		client:        client,
-		contractAddr:  common.HexToAddress(contractAddr),
-		jobCreatedSig: jobCreatedSig,
-	}, nil
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain id: %w", err)
+	}
+	confirmationDepth := config.ConfirmationDepths[chainID.Uint64()]
+	log.Printf("Acting on events %d blocks deep on chain %s", confirmationDepth, chainID)
+	serviceManager, err := servicemanager.NewContractKeeperNetworkServiceManagerCaller(common.HexToAddress(config.ServiceManagerAddr), client)
+	if err != nil {
+		return nil, err
+	}
+	jobManagerAddr, err := serviceManager.KeeperNetworkJobManager(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job manager address: %w", err)
+	}
+	taskManagerAddr, err := serviceManager.KeeperNetworkTaskManager(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task manager address: %w", err)
+	}
+	if err := checkAddress("job manager", config.JobManagerAddr, jobManagerAddr); err != nil {
+		return nil, err
+	}
+	if err := checkAddress("task manager", config.TaskManagerAddr, taskManagerAddr); err != nil {
+		return nil, err
+	}
+	jobManager, err := jobmanager.NewContractKeeperNetworkJobManagerCaller(jobManagerAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := newLogDecoder(jobManagerAddr, taskManagerAddr)
+	if err != nil {
+		return nil, err
+	}
+	taskManager, err := taskmanagerbinding.NewContractKeeperNetworkTaskManager(taskManagerAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	var writer TaskWriter
+	if config.PrivateKey != nil {
+		transactOpts, err := bind.NewKeyedTransactorWithChainID(config.PrivateKey, chainID)
+		if err != nil {
+			return nil, err
+		}
+		writer = &chainTaskWriter{client: client, taskManager: taskManager, opts: transactOpts}
+	} else {
+		log.Printf("No private key, tasks will not be recorded on chain")
+	}
+	operators, err := newOperatorRegistry(client, serviceManager, taskManager, common.HexToAddress(config.OperatorStateRetrieverAddr), config.OperatorSocketsStartBlock, config.OperatorSocketsPageSize)
+	if err != nil {
+		return nil, err
+	}
+	tm := &TaskManager{
+		config:            config,
+		client:            client,
+		cursor:            cursor,
+		jobManagerAddr:    jobManagerAddr,
+		taskManagerAddr:   taskManagerAddr,
+		jobManager:        jobManager,
+		writer:            writer,
+		operators:         operators,
+		policy:            policy,
+		pending:           make(map[uint32]*pendingTask),
+		loads:             make(map[common.Address]int),
+		records:           make(map[common.Address]OperatorRecord),
+		responded:         make(map[uint32]respondedTask),
+		decoder:           decoder,
+		confirmer:         reorg.NewConfirmer[struct{}](confirmationDepth, client),
+		confirmationDepth: confirmationDepth,
+		metrics:           NewMetrics(config.Registry),
+	}
+	tm.eventJobs = newEventJobs(tm, client)
+	tm.scheduler, err = scheduler.New(config.SchedulesPath, tm.runSchedule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schedules from %s: %w", config.SchedulesPath, err)
+	}
+	return tm, nil
 }
 
-func (tm *TaskManager) ListenForEvents() {
-	query := ethereum.FilterQuery{
-		Addresses: []common.Address{tm.contractAddr},
-		Topics:    [][]common.Hash{{tm.jobCreatedSig}},
+// checkAddress fails if the configured address of a contract is set and is not the actual one.
+func checkAddress(contract, configured string, actual common.Address) error {
+	if configured != "" && common.HexToAddress(configured) != actual {
+		return fmt.Errorf("configured %s %s is not the service manager's %s %s", contract, configured, contract, actual.Hex())
 	}
+	return nil
+}
 
-	logs := make(chan types.Log)
+// ListenForEvents processes the events emitted since the cursor, then the new ones as they come,
+// and does not return. Events are followed over websocket, or polled for over http while the
+// websocket is down (see logstream.Stream).
+//
+// Events are processed once they are confirmed (see reorg.Confirmer). When a reorg removes a
+// processed event, what was done for it is undone, and the cursor moves back to the fork point so
+// that the events of the new chain are processed.
+func (tm *TaskManager) ListenForEvents() {
 	ctx := context.Background()
-
-	sub, err := tm.client.SubscribeFilterLogs(ctx, query, logs)
-	if err != nil {
-		log.Fatalf("Failed to subscribe to filter logs: %v", err)
+	go tm.scheduler.Run(ctx)
+	go tm.watchDeadlines(ctx)
+	go tm.config.Notifier.Run(ctx)
+	for _, schedule := range tm.scheduler.List() {
+		if schedule.Trigger.Kind == scheduler.TriggerEvent {
+			tm.watchJobEvent(ctx, schedule)
+		}
 	}
-
-	for {
-		select {
-		case err := <-sub.Err():
-			log.Fatalf("Subscription error: %v", err)
-		case vLog := <-logs:
-			log.Printf("Received JobCreated event log: %+v\n", vLog)
-			tm.AllocateTasks(vLog)
+	stream := tm.newLogStream(tm.client, tm.filterQuery(), tm.metrics)
+	stream.OnHead = func(ctx context.Context, head *types.Header) {
+		tm.addHead(ctx, stream, head)
+	}
+	stream.OnLog = func(ctx context.Context, vLog types.Log) {
+		if vLog.Removed || !tm.cursor.processed(vLog) {
+			tm.handleUpdate(ctx, stream, tm.confirmer.AddLog(vLog, struct{}{}))
 		}
 	}
+	stream.Run(ctx, tm.cursor.resumeBlock(tm.config.StartBlock))
 }
 
-func (tm *TaskManager) AllocateTasks(vLog types.Log) {
-	// Decode the event log
-	var jobCreatedEvent JobCreatedEvent
-	data := vLog.Data
-	if err := decodeEventData(data, &jobCreatedEvent); err != nil {
-		log.Printf("Failed to decode event data: %v", err)
+func (tm *TaskManager) addHead(ctx context.Context, stream *logstream.Stream, head *types.Header) {
+	update, err := tm.confirmer.AddHead(ctx, head)
+	if err != nil {
+		log.Printf("Failed to track block %d: %v", head.Number, err)
 		return
 	}
+	tm.handleUpdate(ctx, stream, update)
+}
 
-	log.Printf("Decoded JobCreated event: %+v\n", jobCreatedEvent)
-
-	// Schedule tasks to send to operator
-	err := tm.scheduleTasks(jobCreatedEvent)
-	if err != nil {
-		log.Printf("Failed to schedule tasks: %v", err)
+// handleUpdate undoes what was done for the removed logs, then processes the confirmed ones. On a
+// reorg, the stream fetches the logs of the new chain from the fork point on.
+func (tm *TaskManager) handleUpdate(ctx context.Context, stream *logstream.Stream, update reorg.Update[struct{}]) {
+	if update.Reorged {
+		log.Printf("Chain reorganization from block %d removed %d processed events", update.ForkPoint, len(update.Removed))
+		if err := tm.cursor.rewind(update.ForkPoint); err != nil {
+			log.Printf("Failed to rewind cursor to block %d: %v", update.ForkPoint, err)
+		}
+		stream.Rewind(update.ForkPoint + 1)
+	}
+	for _, event := range update.Removed {
+		tm.undoLog(ctx, event.Log)
+	}
+	for _, event := range update.Confirmed {
+		tm.processLog(ctx, event.Log)
 	}
 }
 
-func decodeEventData(data []byte, event *JobCreatedEvent) error {
-	if len(data) < 160 {
-		return fmt.Errorf("invalid event data length")
+func (tm *TaskManager) filterQuery() ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{tm.jobManagerAddr, tm.taskManagerAddr},
+		Topics:    [][]common.Hash{tm.decoder.topics()},
 	}
+}
 
-	// Decode the uint32 jobID
-	jobID := new(big.Int).SetBytes(data[:32]).Uint64()
-	event.JobID = uint32(jobID)
+// processLog handles a log once: logs at or before the cursor are skipped, and
+// the cursor moves past every other log, including the ones that fail to decode.
+func (tm *TaskManager) processLog(ctx context.Context, vLog types.Log) {
+	if tm.cursor.processed(vLog) {
+		return
+	}
+	tm.handleLog(ctx, vLog)
+	if err := tm.cursor.advance(vLog); err != nil {
+		log.Printf("Failed to save cursor at block %d log %d: %v", vLog.BlockNumber, vLog.Index, err)
+	}
+}
 
-	// Decode the timeframe
-	timeframe := new(big.Int).SetBytes(data[32:64]).Uint64()
-	event.Timeframe = uint32(timeframe)
+func (tm *TaskManager) handleLog(ctx context.Context, vLog types.Log) {
+	event, err := tm.decoder.decode(vLog)
+	if err != nil {
+		log.Printf("Skipping log: %v", err)
+		return
+	}
 
-	// Decode the offsets
-	offset0 := new(big.Int).SetBytes(data[64:96]).Uint64()
-	offset1 := new(big.Int).SetBytes(data[96:128]).Uint64()
-	offset2 := new(big.Int).SetBytes(data[128:160]).Uint64()
+	switch event := event.(type) {
+	case *jobmanager.ContractKeeperNetworkJobManagerJobCreated:
+		log.Printf("Received JobCreated event: jobId %d, jobType %q, gitlink %q", event.JobId, event.JobType, event.Gitlink)
+		tm.config.Notifier.Notify(notify.Event{
+			Type:    notify.JobCreated,
+			JobID:   event.JobId,
+			Message: fmt.Sprintf("Job %d of type %q was created", event.JobId, event.JobType),
+		})
+		tm.AllocateTasks(ctx, event.JobId, vLog.BlockNumber)
+	case *jobmanager.ContractKeeperNetworkJobManagerJobDeleted:
+		log.Printf("Received JobDeleted event: jobId %d", event.JobId)
+		tm.unscheduleJob(event.JobId)
+		tm.deleteEventCursor(event.JobId)
+	case *jobmanager.ContractKeeperNetworkJobManagerJobStatusUpdated:
+		log.Printf("Received JobStatusUpdated event: jobId %d, status %q", event.JobId, event.Status)
+		if !jobStatusActive(event.Status) {
+			tm.unscheduleJob(event.JobId)
+		} else if _, ok := tm.scheduler.Get(event.JobId); !ok {
+			// a paused job that is resumed is scheduled again, after its last run,
+			// and reacts to the events from its resumption on
+			tm.AllocateTasks(ctx, event.JobId, vLog.BlockNumber)
+		}
+	case *taskmanagerbinding.ContractKeeperNetworkTaskManagerTaskCreated:
+		log.Printf("Received TaskCreated event: taskId %d, jobId %d, taskType %q", event.TaskId, event.JobId, event.TaskType)
+	case *taskmanagerbinding.ContractKeeperNetworkTaskManagerTaskResponded:
+		log.Printf("Received TaskResponded event: taskId %d, status %d", event.TaskResponse.ReferenceTaskId, event.TaskResponse.Status)
+		tm.completeTask(event.TaskResponse.ReferenceTaskId, vLog.BlockNumber)
+	case *taskmanagerbinding.ContractKeeperNetworkTaskManagerTaskCompleted:
+		// emitted when the aggregator responds to the task with the operators' signatures
+		log.Printf("Received TaskCompleted event: taskId %d", event.TaskId)
+		tm.completeTask(event.TaskId, vLog.BlockNumber)
+	default:
+		log.Printf("Received event: %+v", event)
+	}
+}
 
-	// Decode jobType
-	jobTypeLength := new(big.Int).SetBytes(data[offset0 : offset0+32]).Uint64()
-	event.JobType = string(data[offset0+32 : offset0+32+jobTypeLength])
+// undoLog compensates for an event a reorg removed after it was processed. If the new chain
+// includes the event again, it is processed again.
+func (tm *TaskManager) undoLog(ctx context.Context, vLog types.Log) {
+	event, err := tm.decoder.decode(vLog)
+	if err != nil {
+		return
+	}
+	switch event := event.(type) {
+	case *jobmanager.ContractKeeperNetworkJobManagerJobCreated:
+		log.Printf("JobCreated event of job %d removed by a reorg, unscheduling it", event.JobId)
+		tm.unscheduleJob(event.JobId)
+	case *jobmanager.ContractKeeperNetworkJobManagerJobDeleted:
+		log.Printf("JobDeleted event of job %d removed by a reorg, restoring it", event.JobId)
+		tm.restoreJob(ctx, event.JobId, vLog.BlockNumber)
+	case *jobmanager.ContractKeeperNetworkJobManagerJobStatusUpdated:
+		log.Printf("JobStatusUpdated event of job %d removed by a reorg, restoring its status", event.JobId)
+		tm.restoreJob(ctx, event.JobId, vLog.BlockNumber)
+	case *taskmanagerbinding.ContractKeeperNetworkTaskManagerTaskResponded:
+		tm.retrackTask(event.TaskResponse.ReferenceTaskId)
+	case *taskmanagerbinding.ContractKeeperNetworkTaskManagerTaskCompleted:
+		tm.retrackTask(event.TaskId)
+	default:
+		log.Printf("Event removed by a reorg: %+v", event)
+	}
+}
 
-	// Decode jobDescription
-	jobDescriptionLength := new(big.Int).SetBytes(data[offset1 : offset1+32]).Uint64()
-	event.JobDescription = string(data[offset1+32 : offset1+32+jobDescriptionLength])
+// restoreJob schedules or unschedules the job to match the status it has on the chain, after a
+// reorg removed an event that changed it. A job that is scheduled again reacts to the events
+// from fromBlock on, the block of the removed event.
+func (tm *TaskManager) restoreJob(ctx context.Context, jobID uint32, fromBlock uint64) {
+	job, err := tm.loadJob(ctx, jobID)
+	if err != nil {
+		log.Printf("Failed to load job %d: %v", jobID, err)
+		return
+	}
+	if !jobStatusActive(job.Status) {
+		tm.unscheduleJob(jobID)
+		return
+	}
+	if _, ok := tm.scheduler.Get(jobID); ok {
+		return
+	}
+	if err := tm.scheduleJob(ctx, job, fromBlock); err != nil {
+		log.Printf("Failed to schedule job %d: %v", jobID, err)
+	}
+}
 
-	// Decode jobURL
-	jobURLLength := new(big.Int).SetBytes(data[offset2 : offset2+32]).Uint64()
-	event.JobURL = string(data[offset2+32 : offset2+32+jobURLLength])
+// AllocateTasks schedules the tasks of the job. fromBlock is the block of the
+// event that started the job, from which an event-triggered job reacts to its event.
+func (tm *TaskManager) AllocateTasks(ctx context.Context, jobID uint32, fromBlock uint64) {
+	job, err := tm.loadJob(ctx, jobID)
+	if err != nil {
+		log.Printf("Failed to load job %d: %v", jobID, err)
+		return
+	}
 
-	// Decode tasks
-	tasksOffset := new(big.Int).SetBytes(data[160:192]).Uint64()
-	tasksLength := new(big.Int).SetBytes(data[tasksOffset : tasksOffset+32]).Uint64()
-	tasksData := data[tasksOffset+32 : tasksOffset+32+tasksLength]
+	log.Printf("Loaded job: %+v\n", job)
 
-	// Parse tasks JSON
-	if err := json.Unmarshal(tasksData, &event.Tasks); err != nil {
-		return fmt.Errorf("failed to unmarshal tasks JSON: %v", err)
+	// Schedule tasks to send to operator
+	err = tm.scheduleJob(ctx, job, fromBlock)
+	if err != nil {
+		log.Printf("Failed to schedule job %d: %v", jobID, err)
 	}
-
-	return nil
 }
 
-func (tm *TaskManager) scheduleTasks(job JobCreatedEvent) error {
-	// Create a cron job to send tasks to operator at equal intervals
-	c := cron.New()
-
-	// Calculate the interval between tasks
-	if len(job.Tasks) == 0 {
-		return fmt.Errorf("no tasks to schedule")
+// loadJob reads the full job from KeeperNetworkJobManager.jobs, since the
+// JobCreated event only carries its id, type and gitlink.
+func (tm *TaskManager) loadJob(ctx context.Context, jobID uint32) (Job, error) {
+	onchainJob, err := tm.jobManager.Jobs(&bind.CallOpts{Context: ctx}, jobID)
+	if err != nil {
+		return Job{}, err
 	}
-
-	interval := job.Timeframe / uint32(len(job.Tasks))
-	if interval == 0 {
-		return fmt.Errorf("timeframe too short for the number of tasks")
+	// jobs() returns a zeroed struct for ids that were never created
+	if onchainJob.JobId == nil || !onchainJob.JobId.IsUint64() || onchainJob.JobId.Uint64() != uint64(jobID) {
+		return Job{}, fmt.Errorf("job %d not found", jobID)
+	}
+	job := Job{
+		JobID:                     jobID,
+		JobType:                   onchainJob.JobType,
+		JobDescription:            onchainJob.JobDescription,
+		JobURL:                    onchainJob.Gitlink,
+		Status:                    onchainJob.Status,
+		QuorumNumbers:             onchainJob.QuorumNumbers,
+		QuorumThresholdPercentage: onchainJob.QuorumThresholdPercentage,
+		Timeframe:                 onchainJob.Timeframe,
 	}
+	if onchainJob.BlockNumber != nil {
+		job.BlockNumber = onchainJob.BlockNumber.Uint64()
+	}
+	return job, nil
+}
 
-	for i, task := range job.Tasks {
-		delay := time.Duration(interval*i) * time.Second
-		cronSchedule := fmt.Sprintf("@every %ds", delay)
+// jobStatusActive reports whether a job in the status should keep running.
+func jobStatusActive(status string) bool {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "paused", "inactive", "cancelled", "canceled", "deleted", "completed":
+		return false
+	default:
+		return true
+	}
+}
 
-		err := c.AddFunc(cronSchedule, func() {
-			err := sendTaskToOperator(task)
-			if err != nil {
-				log.Printf("Failed to send task to operator: %v", err)
+// jobTrigger reads the trigger of a job from the "schedule:" line of its
+// description, e.g. "schedule: every 5m" or "schedule: cron 0 * * * *", or
+// from its "event:" line (see package eventtrigger). Jobs without either run once.
+func jobTrigger(description string) (scheduler.Trigger, error) {
+	event, err := eventtrigger.Parse(description)
+	if err != nil {
+		return scheduler.Trigger{}, err
+	}
+	for _, line := range strings.Split(description, "\n") {
+		key, spec, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(key), "schedule") {
+			if event != nil {
+				return scheduler.Trigger{}, fmt.Errorf("a job runs either on a schedule or on an event, not both")
 			}
-		})
-
-		if err != nil {
-			return fmt.Errorf("failed to add cron job: %v", err)
+			return scheduler.ParseTrigger(spec)
 		}
 	}
-
-	c.Start()
-	defer c.Stop()
-
-	return nil
+	if event != nil {
+		return scheduler.Trigger{Kind: scheduler.TriggerEvent}, nil
+	}
+	return scheduler.Trigger{Kind: scheduler.TriggerOnce}, nil
 }
 
-func sendTaskToOperator(task Task) error {
-	taskJSON, err := json.Marshal(task)
+// scheduleJob schedules the job from the time of the block it was created in,
+// until its timeframe is over. An event-triggered job reacts to the events
+// from fromBlock on.
+func (tm *TaskManager) scheduleJob(ctx context.Context, job Job, fromBlock uint64) error {
+	trigger, err := jobTrigger(job.JobDescription)
 	if err != nil {
 		return err
 	}
-
-	resp, err := http.Post("http://localhost:8081/executeTask", "application/json", strings.NewReader(string(taskJSON)))
+	start := time.Now()
+	if job.BlockNumber != 0 {
+		header, err := tm.client.HeaderByNumber(ctx, new(big.Int).SetUint64(job.BlockNumber))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", job.BlockNumber, err)
+		}
+		start = time.Unix(int64(header.Time), 0)
+	}
+	var end time.Time
+	if job.Timeframe != 0 {
+		end = start.Add(time.Duration(job.Timeframe) * time.Second)
+	}
+	payload, err := json.Marshal(scheduledTask{
+		OperatorTask: OperatorTask{
+			JobID:          job.JobID,
+			JobType:        job.JobType,
+			JobDescription: job.JobDescription,
+			JobURL:         job.JobURL,
+		},
+		QuorumNumbers:             job.QuorumNumbers,
+		QuorumThresholdPercentage: job.QuorumThresholdPercentage,
+		FromBlock:                 fromBlock,
+	})
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to send task: %s", resp.Status)
+	// a replaced event job resumes after the last log it ran for, from fromBlock at the earliest
+	tm.stopEventWatch(job.JobID)
+	schedule := scheduler.Schedule{
+		JobID:   job.JobID,
+		Trigger: trigger,
+		Start:   start,
+		End:     end,
+		Payload: payload,
+	}
+	scheduled, err := tm.scheduler.Add(schedule)
+	if err != nil {
+		return err
+	}
+	if !scheduled {
+		log.Printf("Job %d is past its timeframe or has made its runs, not scheduling it", job.JobID)
+		return nil
+	}
+	log.Printf("Scheduled job %d to run %s from %s", job.JobID, trigger, start.Format(time.RFC3339))
+	if trigger.Kind == scheduler.TriggerEvent {
+		tm.watchJobEvent(ctx, schedule)
 	}
-
 	return nil
 }
+
+// unscheduleJob stops sending the job's tasks. A task that was already sent cannot be taken back.
+func (tm *TaskManager) unscheduleJob(jobID uint32) {
+	tm.stopEventWatch(jobID)
+	cancelled, err := tm.scheduler.Cancel(jobID)
+	if err != nil {
+		log.Printf("Failed to save schedules after cancelling job %d: %v", jobID, err)
+	}
+	if cancelled {
+		log.Printf("Cancelled schedule of job %d", jobID)
+	}
+}
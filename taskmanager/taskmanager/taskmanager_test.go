@@ -0,0 +1,200 @@
+package taskmanager
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	jobmanager "taskmanager/bindings/KeeperNetworkJobManager"
+	taskmanagerbinding "taskmanager/bindings/KeeperNetworkTaskManager"
+	"taskmanager/scheduler"
+)
+
+// fakeJobManager answers KeeperNetworkJobManager.jobs with a job in the set status,
+// or with a zeroed job once it is deleted.
+type fakeJobManager struct {
+	mu      sync.Mutex
+	status  string
+	deleted bool
+}
+
+func (f *fakeJobManager) setStatus(status string, deleted bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status, f.deleted = status, deleted
+}
+
+func (f *fakeJobManager) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (f *fakeJobManager) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	jobManagerAbi, err := jobmanager.ContractKeeperNetworkJobManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	method, err := jobManagerAbi.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.deleted {
+		return method.Outputs.Pack(new(big.Int), "", "", "", "", []byte{}, uint32(0), uint32(0), new(big.Int))
+	}
+	return method.Outputs.Pack(new(big.Int).SetUint64(uint64(args[0].(uint32))), "js", "schedule: every 5m",
+		"https://example.com/job.js", f.status, []byte{0}, uint32(67), uint32(0), new(big.Int))
+}
+
+func testUndoTaskManager(t *testing.T, chain *fakeJobManager) *TaskManager {
+	t.Helper()
+	decoder, err := newLogDecoder(testJobManagerAddr, testTaskManagerAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobManager, err := jobmanager.NewContractKeeperNetworkJobManagerCaller(testJobManagerAddr, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm := testTrackingTaskManager()
+	tm.config.EventCursorsDir = t.TempDir()
+	tm.jobManager = jobManager
+	tm.decoder = decoder
+	tm.eventJobs = newEventJobs(tm, nil)
+	tm.scheduler, err = scheduler.New(filepath.Join(t.TempDir(), "schedules.json"), func(scheduler.Schedule) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tm
+}
+
+// eventLog builds the log of a contract event from its indexed topics and its other fields.
+func eventLog(t *testing.T, getAbi func() (*abi.ABI, error), address common.Address, name string, topics []common.Hash, fields ...interface{}) types.Log {
+	t.Helper()
+	contractAbi, err := getAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := contractAbi.Events[name]
+	data, err := event.Inputs.NonIndexed().Pack(fields...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.Log{
+		Address:     address,
+		Topics:      append([]common.Hash{event.ID}, topics...),
+		Data:        data,
+		BlockNumber: 10,
+	}
+}
+
+func idTopic(id uint32) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(uint64(id)))
+}
+
+func TestUndoJobDeletedReschedulesTheJob(t *testing.T) {
+	chain := &fakeJobManager{status: "active"}
+	tm := testUndoTaskManager(t, chain)
+	ctx := context.Background()
+	tm.AllocateTasks(ctx, 1, 5)
+
+	jobDeleted := eventLog(t, jobmanager.ContractKeeperNetworkJobManagerMetaData.GetAbi, testJobManagerAddr, "JobDeleted", []common.Hash{idTopic(1)})
+	chain.setStatus("", true)
+	tm.handleLog(ctx, jobDeleted)
+	if _, ok := tm.scheduler.Get(1); ok {
+		t.Fatal("expected the deleted job to be unscheduled")
+	}
+
+	// the new chain does not delete the job
+	chain.setStatus("active", false)
+	tm.undoLog(ctx, jobDeleted)
+	if _, ok := tm.scheduler.Get(1); !ok {
+		t.Error("expected the job to be scheduled again")
+	}
+}
+
+func TestUndoJobStatusUpdatedRestoresTheStatus(t *testing.T) {
+	chain := &fakeJobManager{status: "active"}
+	tm := testUndoTaskManager(t, chain)
+	ctx := context.Background()
+	tm.AllocateTasks(ctx, 1, 5)
+	getAbi := jobmanager.ContractKeeperNetworkJobManagerMetaData.GetAbi
+
+	paused := eventLog(t, getAbi, testJobManagerAddr, "JobStatusUpdated", []common.Hash{idTopic(1)}, "paused")
+	chain.setStatus("paused", false)
+	tm.handleLog(ctx, paused)
+	if _, ok := tm.scheduler.Get(1); ok {
+		t.Fatal("expected the paused job to be unscheduled")
+	}
+	chain.setStatus("active", false)
+	tm.undoLog(ctx, paused)
+	if _, ok := tm.scheduler.Get(1); !ok {
+		t.Fatal("expected the job to be active again")
+	}
+
+	// the job is paused on the new chain, and was resumed on the old one
+	chain.setStatus("paused", false)
+	tm.handleLog(ctx, paused)
+	resumed := eventLog(t, getAbi, testJobManagerAddr, "JobStatusUpdated", []common.Hash{idTopic(1)}, "active")
+	chain.setStatus("active", false)
+	tm.handleLog(ctx, resumed)
+	if _, ok := tm.scheduler.Get(1); !ok {
+		t.Fatal("expected the resumed job to be scheduled")
+	}
+	chain.setStatus("paused", false)
+	tm.undoLog(ctx, resumed)
+	if _, ok := tm.scheduler.Get(1); ok {
+		t.Error("expected the job to be paused again")
+	}
+}
+
+func TestUndoTaskResponseRetracksTheTask(t *testing.T) {
+	tm := testUndoTaskManager(t, &fakeJobManager{})
+	ctx := context.Background()
+	getAbi := taskmanagerbinding.ContractKeeperNetworkTaskManagerMetaData.GetAbi
+	operator := testOperators(1)[0]
+
+	responded := eventLog(t, getAbi, testTaskManagerAddr, "TaskResponded", nil,
+		taskmanagerbinding.IKeeperNetworkTaskManagerTaskResponse{ReferenceTaskId: 1, JobId: 1},
+		taskmanagerbinding.IKeeperNetworkTaskManagerTaskResponseMetadata{TaskResponsedBlock: big.NewInt(10)})
+	completed := eventLog(t, getAbi, testTaskManagerAddr, "TaskCompleted", []common.Hash{idTopic(2)})
+	for taskID, response := range map[uint32]types.Log{1: responded, 2: completed} {
+		pending := &pendingTask{task: scheduledTask{OperatorTask: OperatorTask{JobID: 1, TaskID: taskID}}, operator: operator.Address, attempts: 1}
+		completedBefore := tm.OperatorRecord(operator.Address).Completed
+		tm.track(pending)
+		tm.handleLog(ctx, response)
+		if len(tm.pending) != 0 {
+			t.Fatalf("task %d: expected the response to complete the task", taskID)
+		}
+
+		tm.undoLog(ctx, response)
+		if tm.pending[taskID] != pending || tm.load(operator.Address) != 1 {
+			t.Fatalf("task %d: expected the task to be tracked again, got %v and a load of %d", taskID, tm.pending, tm.load(operator.Address))
+		}
+		if record := tm.OperatorRecord(operator.Address); record.Completed != completedBefore {
+			t.Errorf("task %d: expected the removed response not to count, got %+v", taskID, record)
+		}
+		if !pending.deadline.After(time.Now()) {
+			t.Errorf("task %d: expected a new deadline, got %s", taskID, pending.deadline)
+		}
+		// a response removed twice is waited for once
+		tm.undoLog(ctx, response)
+		if tm.load(operator.Address) != 1 {
+			t.Errorf("task %d: expected the task to be tracked once, got a load of %d", taskID, tm.load(operator.Address))
+		}
+		// the new chain includes the response again
+		tm.handleLog(ctx, response)
+	}
+}
@@ -0,0 +1,103 @@
+package taskmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	taskmanagerbinding "taskmanager/bindings/KeeperNetworkTaskManager"
+)
+
+// The onchain statuses of a task, from its creation until it is responded to
+// or failed. They are the same as the aggregator's, see chainio.AvsWriterer.
+const (
+	TaskStatusCreated   = "created"
+	TaskStatusAssigned  = "assigned"
+	TaskStatusResponded = "responded"
+	TaskStatusFailed    = "failed"
+)
+
+// TaskWriter records the lifecycle of the tasks on KeeperNetworkTaskManager, so that
+// challengers and job owners can check which operator was given which task and how it went.
+type TaskWriter interface {
+	// CreateTask creates a task of the job with status TaskStatusCreated, and returns its id.
+	CreateTask(ctx context.Context, jobID uint32, taskType string) (uint32, error)
+	AssignTask(ctx context.Context, taskID uint32, operator common.Address) error
+	UpdateTaskStatus(ctx context.Context, taskID uint32, status string) error
+}
+
+// chainTaskWriter sends the transactions with a key, and waits for them to be mined.
+type chainTaskWriter struct {
+	client      bind.DeployBackend
+	taskManager *taskmanagerbinding.ContractKeeperNetworkTaskManager
+	opts        *bind.TransactOpts
+	// transactions are sent one at a time, so that concurrent runs do not reuse a nonce
+	mu sync.Mutex
+}
+
+var _ TaskWriter = (*chainTaskWriter)(nil)
+
+func (w *chainTaskWriter) CreateTask(ctx context.Context, jobID uint32, taskType string) (uint32, error) {
+	receipt, err := w.transact(ctx, "createTask", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return w.taskManager.CreateTask(opts, jobID, taskType, TaskStatusCreated)
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, vLog := range receipt.Logs {
+		created, err := w.taskManager.ParseTaskCreated(*vLog)
+		if err == nil {
+			return created.TaskId, nil
+		}
+	}
+	return 0, fmt.Errorf("createTask transaction %s has no TaskCreated event", receipt.TxHash.Hex())
+}
+
+func (w *chainTaskWriter) AssignTask(ctx context.Context, taskID uint32, operator common.Address) error {
+	_, err := w.transact(ctx, "assignTask", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return w.taskManager.AssignTask(opts, taskID, operator)
+	})
+	return err
+}
+
+func (w *chainTaskWriter) UpdateTaskStatus(ctx context.Context, taskID uint32, status string) error {
+	_, err := w.transact(ctx, "updateTaskStatus", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return w.taskManager.UpdateTaskStatus(opts, taskID, status)
+	})
+	return err
+}
+
+func (w *chainTaskWriter) transact(ctx context.Context, method string, send func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Receipt, error) {
+	opts := *w.opts
+	opts.Context = ctx
+	w.mu.Lock()
+	tx, err := send(&opts)
+	w.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s transaction: %w", method, err)
+	}
+	receipt, err := bind.WaitMined(ctx, w.client, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for %s transaction %s: %w", method, tx.Hash().Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("%s transaction %s reverted", method, tx.Hash().Hex())
+	}
+	return receipt, nil
+}
+
+// updateTaskStatus records the status of a task created on chain. A status
+// that cannot be recorded is logged, and does not stop the task.
+func (tm *TaskManager) updateTaskStatus(ctx context.Context, taskID uint32, status string) {
+	if tm.writer == nil || taskID == 0 {
+		return
+	}
+	if err := tm.writer.UpdateTaskStatus(ctx, taskID, status); err != nil {
+		log.Printf("Failed to set status of task %d to %q: %v", taskID, status, err)
+	}
+}
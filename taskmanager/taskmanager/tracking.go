@@ -0,0 +1,264 @@
+package taskmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"taskmanager/notify"
+)
+
+const (
+	defaultTaskTimeout         = 5 * time.Minute
+	defaultMaxTaskAttempts     = 3
+	defaultMaxOperatorFailures = 3
+	deadlineCheckInterval      = time.Second
+	// how many blocks past the confirmation depth a responded task is kept,
+	// in case a reorg removes its response
+	respondedTaskBlocks = 256
+
+	reasonMissedDeadline = "no response by the deadline"
+)
+
+// pendingTask is a task sent to an operator that has not responded yet.
+type pendingTask struct {
+	task     scheduledTask
+	operator common.Address
+	// operators the task was given to, or could not be sent to for
+	// attesting, which it is not given to again. Guarded by pendingMu, like
+	// attesters.
+	tried map[common.Address]bool
+	// operators the task was sent to only to attest to its response. They
+	// are the first the task is reassigned to, see promotable.
+	attesters map[common.Address]bool
+	attempts  int
+	deadline  time.Time
+	// end is the end of the job's timeframe, after which the task is not
+	// given to another operator. A zero end means it has none.
+	end time.Time
+}
+
+// respondedTask is a task whose response was seen on chain at block.
+type respondedTask struct {
+	pending *pendingTask
+	block   uint64
+}
+
+// OperatorRecord is what the task manager saw of an operator's tasks.
+type OperatorRecord struct {
+	Completed uint64
+	// Failed counts the tasks the operator did not respond to in time, or could not be sent.
+	Failed uint64
+	// ConsecutiveFailures is reset when the operator responds to a task.
+	ConsecutiveFailures int
+}
+
+// deadline returns the deadline of a task given to an operator at now: the task timeout
+// later, or the end of the job's timeframe if that is earlier.
+func (tm *TaskManager) deadline(now, end time.Time) time.Time {
+	deadline := now.Add(tm.config.TaskTimeout)
+	if !end.IsZero() && end.Before(deadline) {
+		return end
+	}
+	return deadline
+}
+
+// track waits for the operator to respond to the task. Tasks that were not
+// created on chain have no id to respond to, and are not tracked.
+func (tm *TaskManager) track(pending *pendingTask) {
+	tm.pendingMu.Lock()
+	defer tm.pendingMu.Unlock()
+	tm.loads[pending.operator]++
+	if pending.task.TaskID != 0 {
+		tm.pending[pending.task.TaskID] = pending
+	}
+}
+
+// untrack stops waiting for the task, and records whether its operator
+// completed it. It returns false if the task was untracked already, e.g.
+// because its response arrived as its deadline passed.
+func (tm *TaskManager) untrack(pending *pendingTask, completed bool) bool {
+	tm.pendingMu.Lock()
+	defer tm.pendingMu.Unlock()
+	if pending.task.TaskID != 0 {
+		if tm.pending[pending.task.TaskID] != pending {
+			return false
+		}
+		delete(tm.pending, pending.task.TaskID)
+	}
+	tm.loads[pending.operator]--
+	if tm.loads[pending.operator] <= 0 {
+		delete(tm.loads, pending.operator)
+	}
+	record := tm.records[pending.operator]
+	if completed {
+		record.Completed++
+		record.ConsecutiveFailures = 0
+	} else {
+		record.Failed++
+		record.ConsecutiveFailures++
+	}
+	tm.records[pending.operator] = record
+	return true
+}
+
+// completeTask is called when a response to the task is seen on chain at block.
+func (tm *TaskManager) completeTask(taskID uint32, block uint64) {
+	tm.pendingMu.Lock()
+	pending, ok := tm.pending[taskID]
+	tm.pendingMu.Unlock()
+	if !ok || !tm.untrack(pending, true) {
+		return
+	}
+	tm.pendingMu.Lock()
+	for id, responded := range tm.responded {
+		if responded.block+tm.confirmationDepth+respondedTaskBlocks < block {
+			delete(tm.responded, id)
+		}
+	}
+	tm.responded[taskID] = respondedTask{pending: pending, block: block}
+	tm.pendingMu.Unlock()
+	log.Printf("Operator %s completed task %d of job %d", pending.operator.Hex(), taskID, pending.task.JobID)
+	tm.updateTaskStatus(context.Background(), taskID, TaskStatusResponded)
+	tm.config.Notifier.Notify(notify.Event{
+		Type:     notify.TaskResponded,
+		JobID:    pending.task.JobID,
+		TaskID:   taskID,
+		Operator: pending.operator.Hex(),
+		Message:  fmt.Sprintf("Operator %s responded to task %d of job %d", pending.operator.Hex(), taskID, pending.task.JobID),
+	})
+}
+
+// retrackTask waits for a response to the task again, from the operator it
+// was given to, after a reorg removed the response that completed it.
+func (tm *TaskManager) retrackTask(taskID uint32) {
+	tm.pendingMu.Lock()
+	responded, ok := tm.responded[taskID]
+	delete(tm.responded, taskID)
+	if ok {
+		record := tm.records[responded.pending.operator]
+		if record.Completed > 0 {
+			record.Completed--
+		}
+		tm.records[responded.pending.operator] = record
+	}
+	tm.pendingMu.Unlock()
+	if !ok {
+		return
+	}
+	pending := responded.pending
+	pending.deadline = tm.deadline(time.Now(), pending.end)
+	tm.track(pending)
+	log.Printf("Response to task %d of job %d removed by a reorg, waiting for operator %s again until %s",
+		taskID, pending.task.JobID, pending.operator.Hex(), pending.deadline.Format(time.RFC3339))
+}
+
+// failTask gives up on the operator of the task, and gives the task to
+// another operator if it has attempts left and its job's timeframe is not over.
+func (tm *TaskManager) failTask(ctx context.Context, pending *pendingTask, reason string) {
+	if !tm.untrack(pending, false) {
+		return
+	}
+	task := pending.task
+	log.Printf("Operator %s failed task %d of job %d: %s", pending.operator.Hex(), task.TaskID, task.JobID, reason)
+	if reason == reasonMissedDeadline {
+		tm.config.Notifier.Notify(notify.Event{
+			Type:     notify.TaskMissedDeadline,
+			JobID:    task.JobID,
+			TaskID:   task.TaskID,
+			Operator: pending.operator.Hex(),
+			Message: fmt.Sprintf("Operator %s did not respond to task %d of job %d by %s",
+				pending.operator.Hex(), task.TaskID, task.JobID, pending.deadline.Format(time.RFC3339)),
+		})
+	}
+	switch {
+	case task.TaskID == 0:
+		// not tracked, so there is no attempt to count
+		tm.giveUp(ctx, pending, "its operator could not be reached")
+	case pending.attempts >= tm.config.MaxTaskAttempts:
+		tm.giveUp(ctx, pending, fmt.Sprintf("it failed %d attempts", pending.attempts))
+	case !pending.end.IsZero() && !time.Now().Before(pending.end):
+		tm.giveUp(ctx, pending, "the job's timeframe is over")
+	default:
+		log.Printf("Reassigning task %d of job %d", task.TaskID, task.JobID)
+		if err := tm.assign(ctx, pending); err != nil {
+			tm.giveUp(ctx, pending, fmt.Sprintf("it could not be reassigned: %v", err))
+		}
+	}
+}
+
+// giveUp marks the task failed on chain, and tells the job's subscribers.
+func (tm *TaskManager) giveUp(ctx context.Context, pending *pendingTask, why string) {
+	task := pending.task
+	log.Printf("Giving up on task %d of job %d since %s", task.TaskID, task.JobID, why)
+	tm.updateTaskStatus(ctx, task.TaskID, TaskStatusFailed)
+	tm.config.Notifier.Notify(notify.Event{
+		Type:     notify.JobFailed,
+		JobID:    task.JobID,
+		TaskID:   task.TaskID,
+		Operator: pending.operator.Hex(),
+		Message:  fmt.Sprintf("Task %d of job %d failed since %s", task.TaskID, task.JobID, why),
+	})
+}
+
+// watchDeadlines fails the tasks whose operators did not respond by their deadline, until ctx is done.
+func (tm *TaskManager) watchDeadlines(ctx context.Context) {
+	ticker := time.NewTicker(deadlineCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			tm.pendingMu.Lock()
+			var expired []*pendingTask
+			for _, pending := range tm.pending {
+				if !now.Before(pending.deadline) {
+					expired = append(expired, pending)
+				}
+			}
+			tm.pendingMu.Unlock()
+			for _, pending := range expired {
+				go tm.failTask(ctx, pending, reasonMissedDeadline)
+			}
+		}
+	}
+}
+
+// eligible returns the candidates the task can be given to: the ones it was
+// not given to before, leaving out the ones that failed their last
+// MaxOperatorFailures tasks unless no other is left.
+func (tm *TaskManager) eligible(candidates []Operator, tried map[common.Address]bool) []Operator {
+	tm.pendingMu.Lock()
+	defer tm.pendingMu.Unlock()
+	var untried, healthy []Operator
+	for _, operator := range candidates {
+		if tried[operator.Address] {
+			continue
+		}
+		untried = append(untried, operator)
+		if tm.records[operator.Address].ConsecutiveFailures < tm.config.MaxOperatorFailures {
+			healthy = append(healthy, operator)
+		}
+	}
+	if len(healthy) > 0 {
+		return healthy
+	}
+	return untried
+}
+
+// OperatorRecord returns what the task manager saw of the operator's tasks since it started.
+func (tm *TaskManager) OperatorRecord(operator common.Address) OperatorRecord {
+	tm.pendingMu.Lock()
+	defer tm.pendingMu.Unlock()
+	return tm.records[operator]
+}
+
+func (tm *TaskManager) load(operator common.Address) int {
+	tm.pendingMu.Lock()
+	defer tm.pendingMu.Unlock()
+	return tm.loads[operator]
+}
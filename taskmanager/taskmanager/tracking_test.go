@@ -0,0 +1,99 @@
+package taskmanager
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"taskmanager/notify"
+)
+
+// fakeTaskWriter records the statuses set on tasks.
+type fakeTaskWriter struct {
+	mu       sync.Mutex
+	statuses map[uint32][]string
+}
+
+func (w *fakeTaskWriter) CreateTask(ctx context.Context, jobID uint32, taskType string) (uint32, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (w *fakeTaskWriter) AssignTask(ctx context.Context, taskID uint32, operator common.Address) error {
+	return errors.New("not implemented")
+}
+
+func (w *fakeTaskWriter) UpdateTaskStatus(ctx context.Context, taskID uint32, status string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.statuses[taskID] = append(w.statuses[taskID], status)
+	return nil
+}
+
+func testTrackingTaskManager() *TaskManager {
+	return &TaskManager{
+		writer:    &fakeTaskWriter{statuses: make(map[uint32][]string)},
+		config:    Config{TaskTimeout: time.Minute, MaxTaskAttempts: 1, MaxOperatorFailures: 1, Notifier: notify.Nop{}},
+		pending:   make(map[uint32]*pendingTask),
+		loads:     make(map[common.Address]int),
+		records:   make(map[common.Address]OperatorRecord),
+		responded: make(map[uint32]respondedTask),
+	}
+}
+
+func TestTaskOutcomesAreRecordedOnce(t *testing.T) {
+	tm := testTrackingTaskManager()
+	operators := testOperators(1, 1)
+	completed := &pendingTask{task: scheduledTask{OperatorTask: OperatorTask{TaskID: 1}}, operator: operators[0].Address, attempts: 1}
+	failed := &pendingTask{task: scheduledTask{OperatorTask: OperatorTask{TaskID: 2}}, operator: operators[1].Address, attempts: 1}
+	tm.track(completed)
+	tm.track(failed)
+	if tm.load(operators[0].Address) != 1 {
+		t.Fatalf("expected a load of 1, got %d", tm.load(operators[0].Address))
+	}
+
+	tm.completeTask(1, 10)
+	// the deadline passing right after the response must not count as a failure
+	tm.failTask(context.Background(), completed, reasonMissedDeadline)
+	tm.failTask(context.Background(), failed, reasonMissedDeadline)
+	tm.completeTask(2, 10)
+
+	if record := tm.OperatorRecord(operators[0].Address); record != (OperatorRecord{Completed: 1}) {
+		t.Errorf("unexpected record of the operator that completed its task: %+v", record)
+	}
+	if record := tm.OperatorRecord(operators[1].Address); record != (OperatorRecord{Failed: 1, ConsecutiveFailures: 1}) {
+		t.Errorf("unexpected record of the operator that failed its task: %+v", record)
+	}
+	statuses := tm.writer.(*fakeTaskWriter).statuses
+	if !reflect.DeepEqual(statuses, map[uint32][]string{1: {TaskStatusResponded}, 2: {TaskStatusFailed}}) {
+		t.Errorf("unexpected onchain statuses %v", statuses)
+	}
+	if len(tm.pending) != 0 || len(tm.loads) != 0 {
+		t.Errorf("expected no pending task, got %d pending and loads %v", len(tm.pending), tm.loads)
+	}
+
+	// the failing operator is only given tasks no other operator is left for
+	if eligible := tm.eligible(operators, nil); len(eligible) != 1 || eligible[0].Address != operators[0].Address {
+		t.Errorf("expected only the healthy operator, got %v", eligible)
+	}
+	tried := map[common.Address]bool{operators[0].Address: true}
+	if eligible := tm.eligible(operators, tried); len(eligible) != 1 || eligible[0].Address != operators[1].Address {
+		t.Errorf("expected the failing operator once the other was tried, got %v", eligible)
+	}
+}
+
+func TestDeadlineIsBoundedByTimeframe(t *testing.T) {
+	tm := testTrackingTaskManager()
+	now := time.Now()
+	if got := tm.deadline(now, time.Time{}); !got.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected the task timeout, got %s", got.Sub(now))
+	}
+	end := now.Add(10 * time.Second)
+	if got := tm.deadline(now, end); !got.Equal(end) {
+		t.Errorf("expected the end of the timeframe, got %s", got.Sub(now))
+	}
+}
@@ -6,6 +6,8 @@ type NodeConfig struct {
 	OperatorAddress               string `yaml:"operator_address"`
 	OperatorStateRetrieverAddress string `yaml:"operator_state_retriever_address"`
 	AVSRegistryCoordinatorAddress string `yaml:"avs_registry_coordinator_address"`
+	// task responses are signed for this task manager only, see core.TaskResponseDomain
+	TaskManagerAddress            string `yaml:"task_manager_address"`
 	TokenStrategyAddr             string `yaml:"token_strategy_addr"`
 	EthRpcUrl                     string `yaml:"eth_rpc_url"`
 	EthWsUrl                      string `yaml:"eth_ws_url"`